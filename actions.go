@@ -0,0 +1,473 @@
+package vrapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Actions let apps read named inputs ("jump", "move") instead of raw
+// controller state. Bindings map controller sources to actions per
+// controller type and hand and can be loaded from JSON so they can be
+// remapped without code changes:
+//
+//	{
+//		"actions": [
+//			{"name": "jump", "type": "bool"},
+//			{"name": "throttle", "type": "axis1d"},
+//			{"name": "move", "type": "axis2d"}
+//		],
+//		"bindings": [
+//			{"action": "jump", "controller": "TrackedRemote", "hand": "right", "source": "A"},
+//			{"action": "jump", "controller": "TrackedRemote", "hand": "left", "source": "IndexTrigger", "threshold": 0.7},
+//			{"action": "throttle", "controller": "TrackedRemote", "source": "GripTrigger"},
+//			{"action": "move", "controller": "TrackedRemote", "hand": "left", "source": "Joystick"}
+//		]
+//	}
+//
+// Sources of a TrackedRemote are the OVRButton names ("A", "Trigger", ...),
+// the OVRTouch names prefixed with "Touch." ("Touch.A", ...), the 1D
+// "IndexTrigger" and "GripTrigger" and the 2D "Joystick",
+// "JoystickNoDeadZone" and "TrackpadPosition". The buttons are also named
+// with a "Button." prefix, the only way to bind the GripTrigger and Joystick
+// buttons since their plain names are taken by the 1D and 2D sources. A StandardPointer has
+// "MenuPressed" and the 1D "PointerStrength". A bool action can be bound to
+// a 1D source. Both it and a 1D action are pressed once the value reaches the
+// binding's threshold (0.5 by default), a 2D action once the length of the
+// value does. Leaving out the hand binds either hand.
+
+type ActionType int
+
+const (
+	ActionBool ActionType = iota
+	ActionAxis1D
+	ActionAxis2D
+)
+
+var actionTypeNames = map[ActionType]string{
+	ActionBool:   "bool",
+	ActionAxis1D: "axis1d",
+	ActionAxis2D: "axis2d",
+}
+
+func (t ActionType) String() string {
+	if name, ok := actionTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ActionType(%d)", int(t))
+}
+
+func (t ActionType) MarshalText() ([]byte, error) {
+	name, ok := actionTypeNames[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown action type %d", int(t))
+	}
+	return []byte(name), nil
+}
+
+func (t *ActionType) UnmarshalText(text []byte) error {
+	for actionType, name := range actionTypeNames {
+		if name == string(text) {
+			*t = actionType
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action type %q", text)
+}
+
+// ActionState is the state of an action after the last ActionSet.Update.
+// A 1D action is pressed while its value is at least a binding's threshold
+// away from zero, a 2D action while the length of its value is, so drift of
+// a joystick without a dead zone does not press it.
+type ActionState struct {
+	Pressed      bool
+	JustPressed  bool // Pressed this Update.
+	JustReleased bool // Released this Update.
+	// Seconds since it was pressed. On the JustReleased Update it is how long
+	// the action was held, 0 after that.
+	HeldFor float64
+
+	Axis1D float32
+	Axis2D mgl.Vec2
+
+	pressedAt float64
+}
+
+// Binding maps a controller source to an action.
+type Binding struct {
+	Action     string
+	Controller OVRControllerType
+	Hand       OVRHandedness // HAND_UNKNOWN binds either hand.
+	Source     string
+	// For a 1D or 2D source, 0 uses 0.5.
+	Threshold float32
+}
+
+// ControllerInput is the state of one device, passed to ActionSet.Update.
+// Only the state matching Type is read.
+type ControllerInput struct {
+	Type       OVRControllerType
+	Handedness OVRHandedness
+
+	TrackedRemote   *OVRInputStateTrackedRemote
+	StandardPointer *OVRInputStateStandardPointer
+}
+
+type sourceValue struct {
+	pressed bool
+	axis1D  float32
+	axis2D  mgl.Vec2
+}
+
+type inputSource struct {
+	kind ActionType
+	read func(in *ControllerInput) sourceValue
+}
+
+var inputSources = map[OVRControllerType]map[string]inputSource{
+	OVRControllerType_TrackedRemote:   trackedRemoteSources(),
+	OVRControllerType_StandardPointer: standardPointerSources(),
+}
+
+func trackedRemoteSources() map[string]inputSource {
+	sources := map[string]inputSource{
+		"IndexTrigger": {ActionAxis1D, func(in *ControllerInput) sourceValue {
+			return sourceValue{axis1D: in.TrackedRemote.IndexTrigger}
+		}},
+		"GripTrigger": {ActionAxis1D, func(in *ControllerInput) sourceValue {
+			return sourceValue{axis1D: in.TrackedRemote.GripTrigger}
+		}},
+		"Joystick": {ActionAxis2D, func(in *ControllerInput) sourceValue {
+			return sourceValue{axis2D: in.TrackedRemote.Joystick}
+		}},
+		"JoystickNoDeadZone": {ActionAxis2D, func(in *ControllerInput) sourceValue {
+			return sourceValue{axis2D: in.TrackedRemote.JoystickNoDeadZone}
+		}},
+		"TrackpadPosition": {ActionAxis2D, func(in *ControllerInput) sourceValue {
+			if in.TrackedRemote.TrackpadStatus == 0 {
+				return sourceValue{}
+			}
+			return sourceValue{axis2D: in.TrackedRemote.TrackpadPosition}
+		}},
+	}
+	for _, n := range buttonNames {
+		button := n.button
+		source := inputSource{ActionBool, func(in *ControllerInput) sourceValue {
+			return sourceValue{pressed: in.TrackedRemote.Buttons&button != 0}
+		}}
+		sources["Button."+n.name] = source
+		// "GripTrigger" and "Joystick" stay the 1D and 2D sources.
+		if _, ok := sources[n.name]; !ok {
+			sources[n.name] = source
+		}
+	}
+	for _, n := range touchNames {
+		touch := n.touch
+		sources["Touch."+n.name] = inputSource{ActionBool, func(in *ControllerInput) sourceValue {
+			return sourceValue{pressed: in.TrackedRemote.Touches&touch != 0}
+		}}
+	}
+	return sources
+}
+
+func standardPointerSources() map[string]inputSource {
+	return map[string]inputSource{
+		"MenuPressed": {ActionBool, func(in *ControllerInput) sourceValue {
+			status := in.StandardPointer.InputStateStatus
+			return sourceValue{pressed: status&OVRInputStateStandardPointerStatus_MenuPressed != 0}
+		}},
+		"PointerStrength": {ActionAxis1D, func(in *ControllerInput) sourceValue {
+			return sourceValue{axis1D: in.StandardPointer.PointerStrength}
+		}},
+	}
+}
+
+func (in *ControllerInput) hasState() bool {
+	switch in.Type {
+	case OVRControllerType_TrackedRemote:
+		return in.TrackedRemote != nil
+	case OVRControllerType_StandardPointer:
+		return in.StandardPointer != nil
+	}
+	return false
+}
+
+type action struct {
+	actionType ActionType
+	state      ActionState
+}
+
+type binding struct {
+	Binding
+	source inputSource
+}
+
+// ActionSet holds the declared actions, their bindings and their state.
+type ActionSet struct {
+	actions  map[string]*action
+	bindings []binding
+}
+
+func NewActionSet() *ActionSet {
+	return &ActionSet{actions: make(map[string]*action)}
+}
+
+func (s *ActionSet) AddAction(name string, actionType ActionType) error {
+	if _, ok := actionTypeNames[actionType]; !ok {
+		return fmt.Errorf("action %q has unknown type %d", name, int(actionType))
+	}
+	if _, ok := s.actions[name]; ok {
+		return fmt.Errorf("action %q declared twice", name)
+	}
+
+	s.actions[name] = &action{actionType: actionType}
+	return nil
+}
+
+// Bind checks the action exists and the source fits its type.
+func (s *ActionSet) Bind(b Binding) error {
+	a, ok := s.actions[b.Action]
+	if !ok {
+		return fmt.Errorf("binding for undeclared action %q", b.Action)
+	}
+	source, ok := inputSources[b.Controller][b.Source]
+	if !ok {
+		return fmt.Errorf("action %q bound to unknown source %q of controller type %d",
+			b.Action, b.Source, b.Controller)
+	}
+
+	fits := source.kind == a.actionType ||
+		(a.actionType == ActionBool && source.kind == ActionAxis1D)
+	if !fits {
+		return fmt.Errorf("%s action %q can not be bound to %s source %q",
+			a.actionType, b.Action, source.kind, b.Source)
+	}
+	if b.Threshold == 0 {
+		b.Threshold = 0.5
+	}
+
+	s.bindings = append(s.bindings, binding{Binding: b, source: source})
+	return nil
+}
+
+// Update reads the bound sources of inputs and updates every action. now is
+// in seconds, e.g. the predicted display time of the frame.
+func (s *ActionSet) Update(now float64, inputs []ControllerInput) {
+	values := make(map[*action]sourceValue, len(s.actions))
+	for i := range inputs {
+		in := &inputs[i]
+		if !in.hasState() {
+			continue
+		}
+
+		for _, b := range s.bindings {
+			if b.Controller != in.Type || (b.Hand != HAND_UNKNOWN && b.Hand != in.Handedness) {
+				continue
+			}
+
+			a := s.actions[b.Action]
+			v := b.source.read(in)
+			switch {
+			case a.actionType == ActionBool && b.source.kind == ActionAxis1D:
+				v = sourceValue{pressed: v.axis1D >= b.Threshold}
+			case a.actionType == ActionAxis1D:
+				v.pressed = abs(v.axis1D) >= b.Threshold
+			case a.actionType == ActionAxis2D:
+				v.pressed = v.axis2D.Len() >= b.Threshold
+			}
+			values[a] = combine(values[a], v)
+		}
+	}
+
+	for _, a := range s.actions {
+		v := values[a]
+		pressed := v.pressed
+
+		state := &a.state
+		state.JustPressed = pressed && !state.Pressed
+		state.JustReleased = !pressed && state.Pressed
+		state.Pressed = pressed
+		state.Axis1D = v.axis1D
+		state.Axis2D = v.axis2D
+		switch {
+		case state.JustPressed:
+			state.pressedAt = now
+			state.HeldFor = 0
+		case pressed, state.JustReleased:
+			state.HeldFor = now - state.pressedAt
+		default:
+			state.HeldFor = 0
+		}
+	}
+}
+
+// combine merges the values of several bindings of one action, pressed if
+// any is and the axis value furthest from zero.
+func combine(a, b sourceValue) sourceValue {
+	a.pressed = a.pressed || b.pressed
+	if abs(b.axis1D) > abs(a.axis1D) {
+		a.axis1D = b.axis1D
+	}
+	if b.axis2D.Len() > a.axis2D.Len() {
+		a.axis2D = b.axis2D
+	}
+	return a
+}
+
+func abs(f float32) float32 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// State returns the state of an action, false if it was never declared.
+func (s *ActionSet) State(name string) (ActionState, bool) {
+	a, ok := s.actions[name]
+	if !ok {
+		return ActionState{}, false
+	}
+	return a.state, true
+}
+
+func (s *ActionSet) Pressed(name string) bool {
+	state, _ := s.State(name)
+	return state.Pressed
+}
+
+func (s *ActionSet) JustPressed(name string) bool {
+	state, _ := s.State(name)
+	return state.JustPressed
+}
+
+func (s *ActionSet) JustReleased(name string) bool {
+	state, _ := s.State(name)
+	return state.JustReleased
+}
+
+func (s *ActionSet) HeldFor(name string) float64 {
+	state, _ := s.State(name)
+	return state.HeldFor
+}
+
+func (s *ActionSet) Axis1D(name string) float32 {
+	state, _ := s.State(name)
+	return state.Axis1D
+}
+
+func (s *ActionSet) Axis2D(name string) mgl.Vec2 {
+	state, _ := s.State(name)
+	return state.Axis2D
+}
+
+// ReadControllerInputs reads the state of every connected device that
+// actions can be bound to.
+func ReadControllerInputs(vrApp *OVRMobile, devices *InputDevices) ([]ControllerInput, error) {
+	var inputs []ControllerInput
+	for _, device := range devices.Devices() {
+		in := ControllerInput{Type: device.Type, Handedness: device.Handedness}
+		switch device.Type {
+		case OVRControllerType_TrackedRemote:
+			state, err := GetTrackedRemoteState(vrApp, device.ID)
+			if err != nil {
+				return nil, err
+			}
+			in.TrackedRemote = &state
+		case OVRControllerType_StandardPointer:
+			state := OVRInputStateStandardPointer{}
+			state.Header.ControllerType = OVRControllerType_StandardPointer
+			if err := GetCurrentInputState(vrApp, device.ID, &state.Header); err != nil {
+				return nil, err
+			}
+			in.StandardPointer = &state
+		default:
+			continue
+		}
+		inputs = append(inputs, in)
+	}
+
+	return inputs, nil
+}
+
+// Files
+
+type actionConfig struct {
+	Actions []struct {
+		Name string     `json:"name"`
+		Type ActionType `json:"type"`
+	} `json:"actions"`
+	Bindings []struct {
+		Action     string  `json:"action"`
+		Controller string  `json:"controller"`
+		Hand       string  `json:"hand"`
+		Source     string  `json:"source"`
+		Threshold  float32 `json:"threshold"`
+	} `json:"bindings"`
+}
+
+var controllerTypeNames = map[string]OVRControllerType{
+	"TrackedRemote":   OVRControllerType_TrackedRemote,
+	"StandardPointer": OVRControllerType_StandardPointer,
+}
+
+var handNames = map[string]OVRHandedness{
+	"":      HAND_UNKNOWN,
+	"any":   HAND_UNKNOWN,
+	"left":  HAND_LEFT,
+	"right": HAND_RIGHT,
+}
+
+// LoadActionSet reads actions and bindings in the JSON format described
+// above.
+func LoadActionSet(r io.Reader) (*ActionSet, error) {
+	var config actionConfig
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&config); err != nil {
+		return nil, fmt.Errorf("decoding action set: %w", err)
+	}
+
+	s := NewActionSet()
+	for _, a := range config.Actions {
+		if err := s.AddAction(a.Name, a.Type); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range config.Bindings {
+		controller, ok := controllerTypeNames[b.Controller]
+		if !ok {
+			return nil, fmt.Errorf("action %q bound to unknown controller %q", b.Action, b.Controller)
+		}
+		hand, ok := handNames[strings.ToLower(b.Hand)]
+		if !ok {
+			return nil, fmt.Errorf("action %q bound to unknown hand %q", b.Action, b.Hand)
+		}
+
+		err := s.Bind(Binding{
+			Action:     b.Action,
+			Controller: controller,
+			Hand:       hand,
+			Source:     b.Source,
+			Threshold:  b.Threshold,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func LoadActionSetFile(path string) (*ActionSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadActionSet(f)
+}
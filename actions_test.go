@@ -0,0 +1,250 @@
+package vrapi
+
+import (
+	"strings"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// The example from the Actions doc comment.
+const exampleActions = `{
+	"actions": [
+		{"name": "jump", "type": "bool"},
+		{"name": "throttle", "type": "axis1d"},
+		{"name": "move", "type": "axis2d"}
+	],
+	"bindings": [
+		{"action": "jump", "controller": "TrackedRemote", "hand": "right", "source": "A"},
+		{"action": "jump", "controller": "TrackedRemote", "hand": "left", "source": "IndexTrigger", "threshold": 0.7},
+		{"action": "throttle", "controller": "TrackedRemote", "source": "GripTrigger"},
+		{"action": "move", "controller": "TrackedRemote", "hand": "left", "source": "Joystick"}
+	]
+}`
+
+func TestActionEdges(t *testing.T) {
+	s, err := LoadActionSet(strings.NewReader(exampleActions))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type remotes struct {
+		left, right OVRInputStateTrackedRemote
+	}
+	frames := []struct {
+		now      float64
+		input    remotes
+		jump     ActionState
+		throttle ActionState
+		move     ActionState
+	}{
+		{
+			now: 1,
+		},
+		{
+			now: 2,
+			input: remotes{
+				right: OVRInputStateTrackedRemote{Buttons: OVRButton_A},
+				left:  OVRInputStateTrackedRemote{GripTrigger: 0.3, Joystick: mgl.Vec2{0.6, 0}},
+			},
+			jump:     ActionState{Pressed: true, JustPressed: true},
+			throttle: ActionState{Axis1D: 0.3},
+			move:     ActionState{Pressed: true, JustPressed: true, Axis2D: mgl.Vec2{0.6, 0}},
+		},
+		{
+			now: 3,
+			input: remotes{
+				right: OVRInputStateTrackedRemote{Buttons: OVRButton_A, GripTrigger: 0.6},
+			},
+			jump:     ActionState{Pressed: true, HeldFor: 1},
+			throttle: ActionState{Pressed: true, JustPressed: true, Axis1D: 0.6},
+			move:     ActionState{JustReleased: true, HeldFor: 1},
+		},
+		{
+			// The left trigger is below the binding's threshold and the
+			// right trigger is not bound.
+			now: 4,
+			input: remotes{
+				left:  OVRInputStateTrackedRemote{IndexTrigger: 0.6, GripTrigger: 0.5},
+				right: OVRInputStateTrackedRemote{IndexTrigger: 0.9},
+			},
+			jump:     ActionState{JustReleased: true, HeldFor: 2},
+			throttle: ActionState{Pressed: true, HeldFor: 1, Axis1D: 0.5},
+		},
+		{
+			now: 5,
+			input: remotes{
+				left: OVRInputStateTrackedRemote{IndexTrigger: 0.8, GripTrigger: 0.2},
+			},
+			jump:     ActionState{Pressed: true, JustPressed: true},
+			throttle: ActionState{JustReleased: true, HeldFor: 2, Axis1D: 0.2},
+		},
+		{
+			now:  6,
+			jump: ActionState{JustReleased: true, HeldFor: 1},
+		},
+		{
+			now: 7,
+		},
+	}
+
+	for _, frame := range frames {
+		left, right := frame.input.left, frame.input.right
+		s.Update(frame.now, []ControllerInput{
+			{Type: OVRControllerType_TrackedRemote, Handedness: HAND_LEFT, TrackedRemote: &left},
+			{Type: OVRControllerType_TrackedRemote, Handedness: HAND_RIGHT, TrackedRemote: &right},
+		})
+
+		for name, want := range map[string]ActionState{
+			"jump":     frame.jump,
+			"throttle": frame.throttle,
+			"move":     frame.move,
+		} {
+			got, ok := s.State(name)
+			if !ok {
+				t.Fatalf("action %q not declared", name)
+			}
+			got.pressedAt = 0
+			if got != want {
+				t.Errorf("at %v %s is %+v, want %+v", frame.now, name, got, want)
+			}
+		}
+	}
+}
+
+// Drift around the centre of a joystick without a dead zone does not press a
+// 2D action, its length has to reach the threshold.
+func TestActionJoystickDrift(t *testing.T) {
+	s, err := LoadActionSet(strings.NewReader(`{
+		"actions": [{"name": "move", "type": "axis2d"}, {"name": "look", "type": "axis2d"}],
+		"bindings": [
+			{"action": "move", "controller": "TrackedRemote", "source": "JoystickNoDeadZone"},
+			{"action": "look", "controller": "TrackedRemote", "source": "JoystickNoDeadZone", "threshold": 0.2}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		joystick   mgl.Vec2
+		move, look bool
+	}{
+		{mgl.Vec2{0.02, -0.01}, false, false},
+		{mgl.Vec2{-0.1, 0.15}, false, false},
+		{mgl.Vec2{0.3, -0.3}, false, true},
+		{mgl.Vec2{-0.4, 0.4}, true, true},
+		{mgl.Vec2{0, -1}, true, true},
+	}
+	for i, test := range tests {
+		remote := OVRInputStateTrackedRemote{JoystickNoDeadZone: test.joystick}
+		s.Update(float64(i), []ControllerInput{
+			{Type: OVRControllerType_TrackedRemote, Handedness: HAND_LEFT, TrackedRemote: &remote},
+		})
+		if got := s.Pressed("move"); got != test.move {
+			t.Errorf("move pressed = %v with the joystick at %v, want %v", got, test.joystick,
+				test.move)
+		}
+		if got := s.Pressed("look"); got != test.look {
+			t.Errorf("look pressed = %v with the joystick at %v, want %v", got, test.joystick,
+				test.look)
+		}
+	}
+}
+
+func TestActionMissingState(t *testing.T) {
+	s, err := LoadActionSet(strings.NewReader(exampleActions))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A remote without its state and a device type nothing binds to.
+	s.Update(1, []ControllerInput{
+		{Type: OVRControllerType_TrackedRemote, Handedness: HAND_RIGHT},
+		{Type: OVRControllerType_Hand, Handedness: HAND_RIGHT},
+	})
+	if s.Pressed("jump") {
+		t.Error("jump pressed without any state")
+	}
+	if _, ok := s.State("fly"); ok {
+		t.Error("State of an undeclared action returned ok")
+	}
+}
+
+func TestLoadActionSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "pointer",
+			config: `{"actions": [{"name": "menu", "type": "bool"}], "bindings": [{"action": "menu", "controller": "StandardPointer", "hand": "Any", "source": "MenuPressed"}]}`,
+		},
+		{
+			name:   "touch",
+			config: `{"actions": [{"name": "rest", "type": "bool"}], "bindings": [{"action": "rest", "controller": "TrackedRemote", "source": "Touch.A"}]}`,
+		},
+		{
+			name:   "clicks",
+			config: `{"actions": [{"name": "grab", "type": "bool"}], "bindings": [{"action": "grab", "controller": "TrackedRemote", "source": "Button.GripTrigger"}, {"action": "grab", "controller": "TrackedRemote", "source": "Button.Joystick"}]}`,
+		},
+		{
+			name:   "invalid json",
+			config: `{"actions": [`,
+			err:    "decoding action set",
+		},
+		{
+			name:   "unknown field",
+			config: `{"actions": [{"name": "jump", "type": "bool", "repeat": true}]}`,
+			err:    "decoding action set",
+		},
+		{
+			name:   "unknown type",
+			config: `{"actions": [{"name": "jump", "type": "axis3d"}]}`,
+			err:    `unknown action type "axis3d"`,
+		},
+		{
+			name:   "declared twice",
+			config: `{"actions": [{"name": "jump", "type": "bool"}, {"name": "jump", "type": "axis1d"}]}`,
+			err:    `action "jump" declared twice`,
+		},
+		{
+			name:   "undeclared action",
+			config: `{"bindings": [{"action": "jump", "controller": "TrackedRemote", "source": "A"}]}`,
+			err:    `binding for undeclared action "jump"`,
+		},
+		{
+			name:   "unknown controller",
+			config: `{"actions": [{"name": "jump", "type": "bool"}], "bindings": [{"action": "jump", "controller": "Gamepad", "source": "A"}]}`,
+			err:    `unknown controller "Gamepad"`,
+		},
+		{
+			name:   "unknown hand",
+			config: `{"actions": [{"name": "jump", "type": "bool"}], "bindings": [{"action": "jump", "controller": "TrackedRemote", "hand": "both", "source": "A"}]}`,
+			err:    `unknown hand "both"`,
+		},
+		{
+			name:   "unknown source",
+			config: `{"actions": [{"name": "jump", "type": "bool"}], "bindings": [{"action": "jump", "controller": "StandardPointer", "source": "A"}]}`,
+			err:    `unknown source "A"`,
+		},
+		{
+			name:   "source does not fit",
+			config: `{"actions": [{"name": "move", "type": "axis2d"}], "bindings": [{"action": "move", "controller": "TrackedRemote", "source": "A"}]}`,
+			err:    `axis2d action "move" can not be bound to bool source "A"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadActionSet(strings.NewReader(test.config))
+			switch {
+			case test.err == "" && err != nil:
+				t.Errorf("LoadActionSet: %v", err)
+			case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+				t.Errorf("LoadActionSet returned %v, want an error containing %q", err, test.err)
+			}
+		})
+	}
+}
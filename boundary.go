@@ -0,0 +1,232 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <VrApi.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Guardian boundary. All positions are in the current tracking space.
+//
+// When the user has not set up a boundary the runtime returns
+// OVRSuccess_BoundaryInvalid, these functions turn that into an *Error so it
+// can be checked with
+//
+//	errors.Is(err, vrapi.OVRSuccess_BoundaryInvalid)
+
+// Guardian boundary trigger state information based on a given tracked
+// device type.
+type OVRBoundaryTriggerResult struct {
+	// Closest point on the boundary surface.
+	ClosestPoint mgl.Vec3
+	// Normal of the closest point on the boundary surface.
+	ClosestPointNormal mgl.Vec3
+	// Distance to the closest guardian boundary surface.
+	ClosestDistance float32
+	// True if the boundary system is being triggered. Due to fade in / out
+	// effects this may not exactly match visibility.
+	IsTriggering bool
+}
+
+func boundaryError(op string, res C.ovrResult) error {
+	if OVRResult(res) == OVRSuccess_BoundaryInvalid {
+		return &Error{Op: op, Code: OVRSuccess_BoundaryInvalid}
+	}
+	return resultError(op, OVRResult(res))
+}
+
+// Times GetBoundaryGeometry asks again when the boundary keeps growing
+// between its calls.
+const boundaryGeometryTries = 3
+
+// GetBoundaryGeometry returns the points of the outer boundary polygon.
+func (c *Context) GetBoundaryGeometry(vrApp *OVRMobile) ([]mgl.Vec3, error) {
+	var points []mgl.Vec3
+	err := c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		for try := 0; try < boundaryGeometryTries; try++ {
+			var count C.uint32_t
+			res := C.vrapi_GetBoundaryGeometry(cOVR, 0, &count, nil)
+			if err := boundaryError("vrapi_GetBoundaryGeometry", res); err != nil {
+				return err
+			}
+			if count == 0 {
+				return nil
+			}
+
+			// The boundary can change in between the calls. Fewer points are
+			// trimmed off, room for one more than asked for shows it grew.
+			buf := make([]mgl.Vec3, count+1)
+			res = C.vrapi_GetBoundaryGeometry(cOVR, count+1, &count,
+				(*C.ovrVector3f)(unsafe.Pointer(&buf[0])))
+			if err := boundaryError("vrapi_GetBoundaryGeometry", res); err != nil {
+				return err
+			}
+			if int(count) < len(buf) {
+				points = buf[:count]
+				return nil
+			}
+		}
+		return fmt.Errorf("vrapi_GetBoundaryGeometry: boundary changed %d times while reading it",
+			boundaryGeometryTries)
+	})
+
+	return points, err
+}
+
+// GetBoundaryOrientedBoundingBox returns the largest box that fits in the
+// boundary. pose is the center and forward facing direction of the box with
+// the orientation converted to Hamilton. halfExtents is half the width,
+// height and depth of the box in meters.
+func (c *Context) GetBoundaryOrientedBoundingBox(vrApp *OVRMobile) (pose OVRPosef,
+	halfExtents mgl.Vec3, err error) {
+
+	err = c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		var cPose C.ovrPosef
+		var cScale C.ovrVector3f
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		res := C.vrapi_GetBoundaryOrientedBoundingBox(cOVR, &cPose, &cScale)
+		if err := boundaryError("vrapi_GetBoundaryOrientedBoundingBox", res); err != nil {
+			return err
+		}
+
+		pose = *(*OVRPosef)(unsafe.Pointer(&cPose))
+		posefToHamilton(&pose)
+		halfExtents = *(*mgl.Vec3)(unsafe.Pointer(&cScale))
+		return nil
+	})
+
+	return pose, halfExtents, err
+}
+
+// TestPointIsInBoundary reports whether point is inside the boundary and how
+// close it is to it.
+func (c *Context) TestPointIsInBoundary(vrApp *OVRMobile,
+	point mgl.Vec3) (inside bool, result OVRBoundaryTriggerResult, err error) {
+
+	err = c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		var cInside C.bool
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		cPoint := *(*C.ovrVector3f)(unsafe.Pointer(&point))
+		cResult := (*C.ovrBoundaryTriggerResult)(unsafe.Pointer(&result))
+		res := C.vrapi_TestPointIsInBoundary(cOVR, cPoint, &cInside, cResult)
+		if err := boundaryError("vrapi_TestPointIsInBoundary", res); err != nil {
+			return err
+		}
+
+		inside = bool(cInside)
+		return nil
+	})
+
+	return inside, result, err
+}
+
+// GetBoundaryTriggerState tests a single tracked device against the boundary.
+func (c *Context) GetBoundaryTriggerState(vrApp *OVRMobile,
+	device OVRTrackedDeviceTypeId) (OVRBoundaryTriggerResult, error) {
+
+	var result OVRBoundaryTriggerResult
+	err := c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+		return boundaryTriggerState(vrApp, device, &result)
+	})
+
+	return result, err
+}
+
+// GetBoundaryTriggerStates tests the HMD and both hands against the boundary.
+// Hands that could not be tested, e.g. a controller that is not connected,
+// are left out of the map. An error is only returned when the HMD fails.
+func (c *Context) GetBoundaryTriggerStates(
+	vrApp *OVRMobile) (map[OVRTrackedDeviceTypeId]OVRBoundaryTriggerResult, error) {
+
+	results := make(map[OVRTrackedDeviceTypeId]OVRBoundaryTriggerResult, NUM_TRACKED_DEVICES)
+	err := c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		for device := TRACKED_DEVICE_HMD; device < NUM_TRACKED_DEVICES; device++ {
+			var result OVRBoundaryTriggerResult
+			if err := boundaryTriggerState(vrApp, device, &result); err != nil {
+				if device == TRACKED_DEVICE_HMD {
+					return err
+				}
+				continue
+			}
+			results[device] = result
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func boundaryTriggerState(vrApp *OVRMobile, device OVRTrackedDeviceTypeId,
+	result *OVRBoundaryTriggerResult) error {
+
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	cResult := (*C.ovrBoundaryTriggerResult)(unsafe.Pointer(result))
+	res := C.vrapi_GetBoundaryTriggerState(cOVR, C.ovrTrackedDeviceTypeId(device), cResult)
+	return boundaryError("vrapi_GetBoundaryTriggerState", res)
+}
+
+// RequestBoundaryVisible forces the boundary to be shown, false returns it to
+// normal operation where it is shown as the user gets close to it.
+func (c *Context) RequestBoundaryVisible(vrApp *OVRMobile, visible bool) error {
+	return c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		res := C.vrapi_RequestBoundaryVisible(cOVR, C.bool(visible))
+		return boundaryError("vrapi_RequestBoundaryVisible", res)
+	})
+}
+
+func (c *Context) GetBoundaryVisible(vrApp *OVRMobile) (bool, error) {
+	var visible bool
+	err := c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		var cVisible C.bool
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		res := C.vrapi_GetBoundaryVisible(cOVR, &cVisible)
+		if err := boundaryError("vrapi_GetBoundaryVisible", res); err != nil {
+			return err
+		}
+
+		visible = bool(cVisible)
+		return nil
+	})
+
+	return visible, err
+}
@@ -0,0 +1,133 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestBoundaryGeometry(t *testing.T) {
+	square := [][3]float32{{-1, 0, -1}, {1, 0, -1}, {1, 0, 1}, {-1, 0, 1}}
+	triangle := [][3]float32{{0, 0, -1}, {1, 0, 1}, {-1, 0, 1}}
+	hexagon := [][3]float32{{-2, 0, 0}, {-1, 0, -2}, {1, 0, -2}, {2, 0, 0}, {1, 0, 2},
+		{-1, 0, 2}}
+
+	tests := []struct {
+		name    string
+		points  [][3]float32
+		changed [][3]float32 // The boundary after the count was asked for.
+		want    [][3]float32
+	}{
+		{name: "no points", points: [][3]float32{}},
+		{name: "square", points: square, want: square},
+		{name: "shrinks in between", points: square, changed: triangle, want: triangle},
+		{name: "grows in between", points: square, changed: hexagon, want: hexagon},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestContext(t)
+			vrApp := enterVrMode(t, c)
+			fakevrapi.SetBoundary(test.points)
+			if test.changed != nil {
+				fakevrapi.ChangeBoundary(test.changed)
+			}
+
+			points, err := c.GetBoundaryGeometry(vrApp)
+			if err != nil {
+				t.Fatalf("GetBoundaryGeometry: %v", err)
+			}
+			var want []mgl.Vec3
+			for _, p := range test.want {
+				want = append(want, mgl.Vec3(p))
+			}
+			if !reflect.DeepEqual(points, want) {
+				t.Errorf("GetBoundaryGeometry() = %v, want %v", points, want)
+			}
+		})
+	}
+}
+
+func TestBoundaryInvalid(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	fakevrapi.SetBoundaryTrigger(int(TRACKED_DEVICE_HMD), fakevrapi.TriggerResult{})
+
+	// The fake starts out without a boundary set up.
+	check := func(op string, err error) {
+		t.Helper()
+		var vrapiErr *Error
+		if !errors.Is(err, OVRSuccess_BoundaryInvalid) || !errors.As(err, &vrapiErr) ||
+			vrapiErr.Op != op {
+			t.Errorf("%s without a boundary returned %v, want an *Error with "+
+				"OVRSuccess_BoundaryInvalid", op, err)
+		}
+	}
+
+	points, err := c.GetBoundaryGeometry(vrApp)
+	check("vrapi_GetBoundaryGeometry", err)
+	if points != nil {
+		t.Errorf("GetBoundaryGeometry without a boundary returned %v", points)
+	}
+	_, err = c.GetBoundaryTriggerState(vrApp, TRACKED_DEVICE_HMD)
+	check("vrapi_GetBoundaryTriggerState", err)
+	results, err := c.GetBoundaryTriggerStates(vrApp)
+	check("vrapi_GetBoundaryTriggerState", err)
+	if results != nil {
+		t.Errorf("GetBoundaryTriggerStates without a boundary returned %v", results)
+	}
+}
+
+func TestBoundaryTriggerStates(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	fakevrapi.SetBoundary([][3]float32{{-1, 0, -1}, {1, 0, -1}, {1, 0, 1}, {-1, 0, 1}})
+
+	// Only the HMD and the right controller are tracked.
+	fakevrapi.SetBoundaryTrigger(int(TRACKED_DEVICE_HMD), fakevrapi.TriggerResult{
+		ClosestPoint:       [3]float32{1, 1.6, 0},
+		ClosestPointNormal: [3]float32{-1, 0, 0},
+		ClosestDistance:    0.8,
+	})
+	fakevrapi.SetBoundaryTrigger(int(TRACKED_DEVICE_HAND_RIGHT), fakevrapi.TriggerResult{
+		ClosestPoint:       [3]float32{1, 1, 0.2},
+		ClosestPointNormal: [3]float32{-1, 0, 0},
+		ClosestDistance:    0.1,
+		IsTriggering:       true,
+	})
+	want := map[OVRTrackedDeviceTypeId]OVRBoundaryTriggerResult{
+		TRACKED_DEVICE_HMD: {
+			ClosestPoint:       mgl.Vec3{1, 1.6, 0},
+			ClosestPointNormal: mgl.Vec3{-1, 0, 0},
+			ClosestDistance:    0.8,
+		},
+		TRACKED_DEVICE_HAND_RIGHT: {
+			ClosestPoint:       mgl.Vec3{1, 1, 0.2},
+			ClosestPointNormal: mgl.Vec3{-1, 0, 0},
+			ClosestDistance:    0.1,
+			IsTriggering:       true,
+		},
+	}
+
+	results, err := c.GetBoundaryTriggerStates(vrApp)
+	if err != nil {
+		t.Fatalf("GetBoundaryTriggerStates: %v", err)
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("GetBoundaryTriggerStates() = %v, want %v", results, want)
+	}
+
+	result, err := c.GetBoundaryTriggerState(vrApp, TRACKED_DEVICE_HAND_RIGHT)
+	if err != nil || result != want[TRACKED_DEVICE_HAND_RIGHT] {
+		t.Errorf("GetBoundaryTriggerState(HAND_RIGHT) = %v, %v, want %v", result, err,
+			want[TRACKED_DEVICE_HAND_RIGHT])
+	}
+	if _, err := c.GetBoundaryTriggerState(vrApp, TRACKED_DEVICE_HAND_LEFT); !errors.Is(err, OVRError_NoDevice) {
+		t.Errorf("GetBoundaryTriggerState(HAND_LEFT) returned %v, want OVRError_NoDevice", err)
+	}
+}
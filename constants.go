@@ -100,6 +100,49 @@ const ( //ovrSystemProperty
 	SYS_PROP_FOVEATION_AVAILABLE OVRSystemProperty = 130
 )
 
+type OVRProperty int32
+
+const ( // ovrProperty
+	// Used by apps that want to control swapchain foveation levels.
+	FOVEATION_LEVEL OVRProperty = 15
+	// Used to tell the runtime not to eat gamepad events. If this is false on
+	// a native app the app must be listening for the events.
+	EAT_NATIVE_GAMEPAD_EVENTS OVRProperty = 20
+	// Used by apps to query which input device is most 'active' or primary,
+	// a -1 means no active input device.
+	ACTIVE_INPUT_DEVICE_ID OVRProperty = 24
+	// Used by apps to determine if they are running in an emulation mode.
+	DEVICE_EMULATION_MODE OVRProperty = 29
+	// Used by apps to enable / disable dynamic foveation adjustments.
+	DYNAMIC_FOVEATION_ENABLED OVRProperty = 30
+)
+
+type OVRTrackingSpace int32
+
+const ( // ovrTrackingSpace
+	// Eye level origin, controlled by system recentering.
+	TRACKING_SPACE_LOCAL OVRTrackingSpace = 0
+	// Floor level origin, controlled by system recentering.
+	TRACKING_SPACE_LOCAL_FLOOR OVRTrackingSpace = 1
+	// Tilted pose for "bed mode", controlled by system recentering.
+	TRACKING_SPACE_LOCAL_TILTED OVRTrackingSpace = 2
+	// Floor level origin, controlled by Guardian setup.
+	TRACKING_SPACE_STAGE OVRTrackingSpace = 3
+	// Position of local space but yaw stays constant.
+	TRACKING_SPACE_LOCAL_FIXED_YAW OVRTrackingSpace = 7
+)
+
+// Tracked device type id used to simplify interaction checks with Guardian.
+type OVRTrackedDeviceTypeId int32
+
+const ( // ovrTrackedDeviceTypeId
+	TRACKED_DEVICE_NONE       OVRTrackedDeviceTypeId = -1
+	TRACKED_DEVICE_HMD        OVRTrackedDeviceTypeId = 0 // Headset
+	TRACKED_DEVICE_HAND_LEFT  OVRTrackedDeviceTypeId = 1 // Left controller
+	TRACKED_DEVICE_HAND_RIGHT OVRTrackedDeviceTypeId = 2 // Right controller
+	NUM_TRACKED_DEVICES       OVRTrackedDeviceTypeId = 3
+)
+
 type OVRControllerType uint32
 
 const ( // OVRControllerType
@@ -132,6 +175,196 @@ const ( // OVRControllerCapabilities
 	OVRControllerCaps_EnumSize                   OVRControllerCapabilities = 0x7fffffff
 )
 
+// Describes button input types. ovrButton_Home, ovrButton_VolUp,
+// ovrButton_VolDown are system buttons never reported to applications.
+// OVRButton_Back is only set for a single frame when a short press (under
+// 0.25 seconds) comes up, long presses are handled by the system.
+type OVRButton uint32
+
+const ( // OVRButton
+	OVRButton_A         OVRButton = 0x00000001 // Set for trigger pulled on the Gear VR and Go Controllers
+	OVRButton_B         OVRButton = 0x00000002
+	OVRButton_RThumb    OVRButton = 0x00000004
+	OVRButton_RShoulder OVRButton = 0x00000008
+
+	OVRButton_X         OVRButton = 0x00000100
+	OVRButton_Y         OVRButton = 0x00000200
+	OVRButton_LThumb    OVRButton = 0x00000400
+	OVRButton_LShoulder OVRButton = 0x00000800
+
+	OVRButton_Up          OVRButton = 0x00010000
+	OVRButton_Down        OVRButton = 0x00020000
+	OVRButton_Left        OVRButton = 0x00040000
+	OVRButton_Right       OVRButton = 0x00080000
+	OVRButton_Enter       OVRButton = 0x00100000 //< Set for touchpad click on the Go Controller, menu button on Left Quest Controller
+	OVRButton_Back        OVRButton = 0x00200000 //< Back button on the Go Controller (only set when a short press comes up)
+	OVRButton_GripTrigger OVRButton = 0x04000000 //< grip trigger engaged
+	OVRButton_Trigger     OVRButton = 0x20000000 //< Index Trigger engaged
+	OVRButton_Joystick    OVRButton = 0x80000000 //< Click of the Joystick
+)
+
+// Describes touch input types. These values map to capacitive touch values
+// and derived pose states.
+type OVRTouch uint32
+
+const ( // OVRTouch
+	OVRTouch_A             OVRTouch = 0x00000001 //< The A button has a finger resting on it.
+	OVRTouch_B             OVRTouch = 0x00000002 //< The B button has a finger resting on it.
+	OVRTouch_X             OVRTouch = 0x00000004 //< The X button has a finger resting on it.
+	OVRTouch_Y             OVRTouch = 0x00000008 //< The Y button has a finger resting on it.
+	OVRTouch_TrackPad      OVRTouch = 0x00000010 //< The TrackPad has a finger resting on it.
+	OVRTouch_Joystick      OVRTouch = 0x00000020 //< The Joystick has a finger resting on it.
+	OVRTouch_IndexTrigger  OVRTouch = 0x00000040 //< The Index Trigger has a finger resting on it.
+	OVRTouch_ThumbUp       OVRTouch = 0x00000100 //< None of A, B, X, Y, or Joystick has a finger/thumb in proximity to it
+	OVRTouch_IndexPointing OVRTouch = 0x00000200 //< The finger is sufficiently far away from the trigger to not be considered in proximity to it.
+	OVRTouch_BaseState     OVRTouch = 0x00000300 //< No buttons touched or in proximity. finger pointing and thumb up.
+	OVRTouch_LThumb        OVRTouch = 0x00000400 //< The Left controller Joystick has a finger/thumb resting on it.
+	OVRTouch_RThumb        OVRTouch = 0x00000800 //< The Right controller Joystick has a finger/thumb resting on it.
+	OVRTouch_ThumbRest     OVRTouch = 0x00001000 // Thumb Rest
+	OVRTouch_LThumbRest    OVRTouch = 0x00002000 // Left Thumb Rest
+	OVRTouch_RThumbRest    OVRTouch = 0x00004000 // Right Thumb Rest
+)
+
+type OVRInputStateStandardPointerStatus uint32
+
+const ( // OVRInputStateStandardPointerStatus
+	// If set the PointerPose and PointerStrength contain valid data,
+	// otherwise they should not be used.
+	OVRInputStateStandardPointerStatus_PointerValid OVRInputStateStandardPointerStatus = (1 << 1)
+	// Hand: the system gesture was performed as the non-dominant hand.
+	// Tracked controller: the menu button was pressed.
+	OVRInputStateStandardPointerStatus_MenuPressed OVRInputStateStandardPointerStatus = (1 << 2)
+)
+
+// Specifies left or right handedness.
+type OVRHandedness int32
+
+const ( // ovrHandedness
+	HAND_UNKNOWN OVRHandedness = 0
+	HAND_LEFT    OVRHandedness = 1
+	HAND_RIGHT   OVRHandedness = 2
+)
+
+type OVRHandCapabilities uint32
+
+const ( // OVRHandCapabilities
+	OVRHandCaps_LeftHand  OVRHandCapabilities = (1 << 0) // if set, this is the left hand
+	OVRHandCaps_RightHand OVRHandCapabilities = (1 << 1) // if set, this is the right hand
+)
+
+type OVRHandStateCapabilities uint32
+
+const ( // OVRHandStateCapabilities
+	OVRHandStateCaps_PinchIndex  OVRHandStateCapabilities = (1 << 0) // if set, index finger pinch is supported
+	OVRHandStateCaps_PinchMiddle OVRHandStateCapabilities = (1 << 1) // if set, middle finger pinch is supported
+	OVRHandStateCaps_PinchRing   OVRHandStateCapabilities = (1 << 2) // if set, ring finger pinch is supported
+	OVRHandStateCaps_PinchPinky  OVRHandStateCapabilities = (1 << 3) // if set, pinky finger pinch is supported
+)
+
+type OVRHandTrackingStatus uint32
+
+const ( // OVRHandTrackingStatus
+	OVRHandTrackingStatus_Untracked OVRHandTrackingStatus = 0
+	OVRHandTrackingStatus_Tracked   OVRHandTrackingStatus = 1
+)
+
+type OVRHandFinger int32
+
+const ( // OVRHandFingers
+	OVRHandFinger_Thumb  OVRHandFinger = 0
+	OVRHandFinger_Index  OVRHandFinger = 1
+	OVRHandFinger_Middle OVRHandFinger = 2
+	OVRHandFinger_Ring   OVRHandFinger = 3
+	OVRHandFinger_Pinky  OVRHandFinger = 4
+	OVRHandFinger_Max    OVRHandFinger = 5
+)
+
+type OVRHandPinchStrength int32
+
+const ( // OVRHandPinchStrength
+	OVRHandPinchStrength_Index  OVRHandPinchStrength = 0 // hand is in the index finger pinch state
+	OVRHandPinchStrength_Middle OVRHandPinchStrength = 1 // hand is in the middle finger pinch state
+	OVRHandPinchStrength_Ring   OVRHandPinchStrength = 2 // hand is in the ring finger pinch state
+	OVRHandPinchStrength_Pinky  OVRHandPinchStrength = 3 // hand is in the pinky finger pinch state
+	OVRHandPinchStrength_Max    OVRHandPinchStrength = 4
+)
+
+// Bone indices, also used as ovrHandBoneIndex.
+type OVRHandBone int16
+
+const ( // OVRHandBone
+	OVRHandBone_Invalid     OVRHandBone = -1
+	OVRHandBone_WristRoot   OVRHandBone = 0  // root frame of the hand, where the wrist is located
+	OVRHandBone_ForearmStub OVRHandBone = 1  // frame for user's forearm
+	OVRHandBone_Thumb0      OVRHandBone = 2  // thumb trapezium bone
+	OVRHandBone_Thumb1      OVRHandBone = 3  // thumb metacarpal bone
+	OVRHandBone_Thumb2      OVRHandBone = 4  // thumb proximal phalange bone
+	OVRHandBone_Thumb3      OVRHandBone = 5  // thumb distal phalange bone
+	OVRHandBone_Index1      OVRHandBone = 6  // index proximal phalange bone
+	OVRHandBone_Index2      OVRHandBone = 7  // index intermediate phalange bone
+	OVRHandBone_Index3      OVRHandBone = 8  // index distal phalange bone
+	OVRHandBone_Middle1     OVRHandBone = 9  // middle proximal phalange bone
+	OVRHandBone_Middle2     OVRHandBone = 10 // middle intermediate phalange bone
+	OVRHandBone_Middle3     OVRHandBone = 11 // middle distal phalange bone
+	OVRHandBone_Ring1       OVRHandBone = 12 // ring proximal phalange bone
+	OVRHandBone_Ring2       OVRHandBone = 13 // ring intermediate phalange bone
+	OVRHandBone_Ring3       OVRHandBone = 14 // ring distal phalange bone
+	OVRHandBone_Pinky0      OVRHandBone = 15 // pinky metacarpal bone
+	OVRHandBone_Pinky1      OVRHandBone = 16 // pinky proximal phalange bone
+	OVRHandBone_Pinky2      OVRHandBone = 17 // pinky intermediate phalange bone
+	OVRHandBone_Pinky3      OVRHandBone = 18 // pinky distal phalange bone
+
+	OVRHandBone_MaxSkinnable OVRHandBone = 19
+
+	// Bone tips are position only. They are not used for skinning but useful
+	// for hit-testing.
+	OVRHandBone_ThumbTip  OVRHandBone = OVRHandBone_MaxSkinnable + 0 // tip of the thumb
+	OVRHandBone_IndexTip  OVRHandBone = OVRHandBone_MaxSkinnable + 1 // tip of the index finger
+	OVRHandBone_MiddleTip OVRHandBone = OVRHandBone_MaxSkinnable + 2 // tip of the middle finger
+	OVRHandBone_RingTip   OVRHandBone = OVRHandBone_MaxSkinnable + 3 // tip of the ring finger
+	OVRHandBone_PinkyTip  OVRHandBone = OVRHandBone_MaxSkinnable + 4 // tip of the pinky
+	OVRHandBone_Max       OVRHandBone = OVRHandBone_MaxSkinnable + 5
+)
+
+// Tracking confidence. HIGH is the bit pattern of 1.0f.
+type OVRConfidence uint32
+
+const ( // OVRConfidence
+	OVRConfidence_LOW  OVRConfidence = 0x00000000
+	OVRConfidence_HIGH OVRConfidence = 0x3f800000
+)
+
+type OVRHandVersion uint32
+
+const ( // OVRHandVersion
+	OVRHandVersion_1 OVRHandVersion = 0xdf000001 // Current
+)
+
+const ( // OVRHandConstants
+	OVRHand_MaxVertices       = 3000
+	OVRHand_MaxIndices        = OVRHand_MaxVertices * 6
+	OVRHand_MaxFingers        = int(OVRHandFinger_Max)
+	OVRHand_MaxPinchStrengths = int(OVRHandPinchStrength_Max)
+	OVRHand_MaxSkinnableBones = int(OVRHandBone_MaxSkinnable)
+	OVRHand_MaxBones          = int(OVRHandBone_Max)
+	OVRHand_MaxCapsules       = 19
+)
+
+type OVRInputStateHandStatus uint32
+
+const ( // OVRInputStateHandStatus
+	// If set the PointerPose and PinchStrength contain valid data, otherwise
+	// they should not be used.
+	OVRInputStateHandStatus_PointerValid            OVRInputStateHandStatus = (1 << 1)
+	OVRInputStateHandStatus_IndexPinching           OVRInputStateHandStatus = (1 << 2) // if set the pinch gesture for that finger is on
+	OVRInputStateHandStatus_MiddlePinching          OVRInputStateHandStatus = (1 << 3) // if set the pinch gesture for that finger is on
+	OVRInputStateHandStatus_RingPinching            OVRInputStateHandStatus = (1 << 4) // if set the pinch gesture for that finger is on
+	OVRInputStateHandStatus_PinkyPinching           OVRInputStateHandStatus = (1 << 5) // if set the pinch gesture for that finger is on
+	OVRInputStateHandStatus_SystemGestureProcessing OVRInputStateHandStatus = (1 << 6) // if set the hand is currently processing a system gesture
+	OVRInputStateHandStatus_DominantHand            OVRInputStateHandStatus = (1 << 7) // if set the hand is considered the dominant hand
+	OVRInputStateHandStatus_MenuPressed             OVRInputStateHandStatus = (1 << 8) // if set the hand performed the system gesture as the non-dominant hand
+)
+
 type OVRLayerType2 uint32
 
 const ( // OVRLayerType2
@@ -189,6 +422,30 @@ const (
 	FRAME_LAYER_FLAG_FILTER_EXPENSIVE OVRFrameLayerFlags = 1 << 19
 )
 
+// Built in convenience swap chains. The runtime treats these values as
+// special swap chain pointers so they never point to real memory, they can
+// only be used in submitted layers.
+const ( // OVRTextureSwapChain
+	DEFAULT_TEXTURE_SWAPCHAIN              OVRTextureSwapChain = 0x1
+	DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON OVRTextureSwapChain = 0x2
+)
+
+type OVRSwapChainCreateFlags uint64
+
+const ( // OVRSwapChainCreateFlags
+	// Image is in subsampled layout.
+	SWAPCHAIN_CREATE_SUBSAMPLED_BIT OVRSwapChainCreateFlags = 0x1
+)
+
+type OVRSwapChainUsageFlags uint64
+
+const ( // OVRSwapChainUsageFlags
+	// Image may be a color rendering target.
+	SWAPCHAIN_USAGE_COLOR_ATTACHMENT_BIT OVRSwapChainUsageFlags = 0x1
+	// Image may be a depth/stencil rendering target.
+	SWAPCHAIN_USAGE_DEPTH_STENCIL_ATTACHMENT_BIT OVRSwapChainUsageFlags = 0x2
+)
+
 type OVRTextureType uint32
 
 const ( // OVRTextureType
@@ -197,3 +454,44 @@ const ( // OVRTextureType
 	TEXTURE_TYPE_CUBE     OVRTextureType = 3 //< Cube maps.
 	TEXTURE_TYPE_MAX      OVRTextureType = 4
 )
+
+// Errors are < 0, successes are >= 0.
+type OVRResult int32
+
+const ( // ovrSuccessResult
+	OVRSuccess                  OVRResult = 0
+	OVRSuccess_BoundaryInvalid  OVRResult = 1001
+	OVRSuccess_EventUnavailable OVRResult = 1002
+	OVRSuccess_Skipped          OVRResult = 1003
+)
+
+const ( // ovrErrorResult
+	OVRError_MemoryAllocationFailure OVRResult = -1000
+	OVRError_NotInitialized          OVRResult = -1004
+	OVRError_InvalidParameter        OVRResult = -1005
+	// Device is not connected, or not connected as input device.
+	OVRError_DeviceUnavailable OVRResult = -1010
+	OVRError_InvalidOperation  OVRResult = -1015
+
+	// Specified device type isn't supported on GearVR.
+	OVRError_UnsupportedDeviceType OVRResult = -1050
+	// Specified device ID does not map to any current device.
+	OVRError_NoDevice OVRResult = -1051
+	// Executed an incomplete code path - this should not be possible in public releases.
+	OVRError_NotImplemented OVRResult = -1052
+	// A subsystem supporting an API is not yet ready.
+	OVRError_NotReady OVRResult = -1053
+	// Data is unavailable.
+	OVRError_Unavailable OVRResult = -1054
+)
+
+type OVRInitializeStatus int32
+
+const ( // OVRInitializeStatus
+	INITIALIZE_SUCCESS                   OVRInitializeStatus = 0
+	INITIALIZE_UNKNOWN_ERROR             OVRInitializeStatus = -1
+	INITIALIZE_PERMISSIONS_ERROR         OVRInitializeStatus = -2
+	INITIALIZE_ALREADY_INITIALIZED       OVRInitializeStatus = -3
+	INITIALIZE_SERVICE_CONNECTION_FAILED OVRInitializeStatus = -4
+	INITIALIZE_DEVICE_NOT_SUPPORTED      OVRInitializeStatus = -5
+)
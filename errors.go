@@ -0,0 +1,107 @@
+package vrapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// Returned when an OVRMobile is used after LeaveVrMode was called on it.
+	ErrLeftVrMode = errors.New("OVRMobile has already left vr mode")
+	// Returned by Shutdown when Initialize has not succeeded or Shutdown has
+	// already been called.
+	ErrNotInitialized = errors.New("vrapi is not initialized")
+	// Returned when a texture swap chain that was destroyed (or was never
+	// created through the Context) is used.
+	ErrSwapChainNotLive = errors.New("texture swap chain is not live")
+)
+
+// SwapChainLeakError is returned by Shutdown, after shutting down, when
+// texture swap chains were never destroyed.
+type SwapChainLeakError struct {
+	SwapChains []OVRTextureSwapChain
+}
+
+func (e *SwapChainLeakError) Error() string {
+	return fmt.Sprintf("%d texture swap chains were never destroyed", len(e.SwapChains))
+}
+
+// Error is returned when a vrapi call fails. Code is the OVRResult (or
+// OVRInitializeStatus for vrapi_Initialize) the runtime returned, so callers
+// can check for a specific failure with
+//
+//	errors.Is(err, vrapi.OVRError_DeviceUnavailable)
+//
+// or get at the code with errors.As.
+type Error struct {
+	Op   string // The vrapi function that failed e.g. "vrapi_SubmitFrame2".
+	Code error  // Either an OVRResult or an OVRInitializeStatus.
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed with %s", e.Op, e.Code)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Code
+}
+
+// resultError returns nil if res is one of the success results otherwise
+// an *Error for op.
+func resultError(op string, res OVRResult) error {
+	if res >= OVRSuccess {
+		return nil
+	}
+	return &Error{Op: op, Code: res}
+}
+
+var resultNames = map[OVRResult]string{
+	OVRSuccess:                  "ovrSuccess",
+	OVRSuccess_BoundaryInvalid:  "ovrSuccess_BoundaryInvalid",
+	OVRSuccess_EventUnavailable: "ovrSuccess_EventUnavailable",
+	OVRSuccess_Skipped:          "ovrSuccess_Skipped",
+
+	OVRError_MemoryAllocationFailure: "ovrError_MemoryAllocationFailure",
+	OVRError_NotInitialized:          "ovrError_NotInitialized",
+	OVRError_InvalidParameter:        "ovrError_InvalidParameter",
+	OVRError_DeviceUnavailable:       "ovrError_DeviceUnavailable",
+	OVRError_InvalidOperation:        "ovrError_InvalidOperation",
+	OVRError_UnsupportedDeviceType:   "ovrError_UnsupportedDeviceType",
+	OVRError_NoDevice:                "ovrError_NoDevice",
+	OVRError_NotImplemented:          "ovrError_NotImplemented",
+	OVRError_NotReady:                "ovrError_NotReady",
+	OVRError_Unavailable:             "ovrError_Unavailable",
+}
+
+func (r OVRResult) String() string {
+	if name, ok := resultNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("ovrResult(%d)", int32(r))
+}
+
+// OVRResult implements error so it can be the target of errors.Is.
+func (r OVRResult) Error() string {
+	return r.String()
+}
+
+var initializeStatusNames = map[OVRInitializeStatus]string{
+	INITIALIZE_SUCCESS:                   "VRAPI_INITIALIZE_SUCCESS",
+	INITIALIZE_UNKNOWN_ERROR:             "VRAPI_INITIALIZE_UNKNOWN_ERROR",
+	INITIALIZE_PERMISSIONS_ERROR:         "VRAPI_INITIALIZE_PERMISSIONS_ERROR",
+	INITIALIZE_ALREADY_INITIALIZED:       "VRAPI_INITIALIZE_ALREADY_INITIALIZED",
+	INITIALIZE_SERVICE_CONNECTION_FAILED: "VRAPI_INITIALIZE_SERVICE_CONNECTION_FAILED",
+	INITIALIZE_DEVICE_NOT_SUPPORTED:      "VRAPI_INITIALIZE_DEVICE_NOT_SUPPORTED",
+}
+
+func (s OVRInitializeStatus) String() string {
+	if name, ok := initializeStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("ovrInitializeStatus(%d)", int32(s))
+}
+
+// OVRInitializeStatus implements error so it can be the target of errors.Is.
+func (s OVRInitializeStatus) Error() string {
+	return s.String()
+}
@@ -0,0 +1,118 @@
+package vrapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	deviceUnavailable := &Error{Op: "vrapi_SubmitFrame2", Code: OVRError_DeviceUnavailable}
+	permissions := &Error{Op: "vrapi_Initialize", Code: INITIALIZE_PERMISSIONS_ERROR}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		is     bool
+	}{
+		{"same result", deviceUnavailable, OVRError_DeviceUnavailable, true},
+		{"other result", deviceUnavailable, OVRError_NotReady, false},
+		{"wrapped", fmt.Errorf("frame: %w", deviceUnavailable), OVRError_DeviceUnavailable, true},
+		{"same status", permissions, INITIALIZE_PERMISSIONS_ERROR, true},
+		{"other status", permissions, INITIALIZE_UNKNOWN_ERROR, false},
+		// Equal numbers of different types are different errors.
+		{"status is not a result", permissions, OVRResult(INITIALIZE_PERMISSIONS_ERROR), false},
+		{"result is not a status", deviceUnavailable,
+			OVRInitializeStatus(OVRError_DeviceUnavailable), false},
+		{"bare result", OVRError_NoDevice, OVRError_NoDevice, true},
+		{"sentinel", fmt.Errorf("shutdown: %w", ErrNotInitialized), ErrNotInitialized, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if is := errors.Is(test.err, test.target); is != test.is {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", test.err, test.target, is, test.is)
+			}
+		})
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		op     string
+		result OVRResult
+		status OVRInitializeStatus
+	}{
+		{
+			name:   "result",
+			err:    fmt.Errorf("frame: %w", &Error{Op: "vrapi_SubmitFrame2", Code: OVRError_NotReady}),
+			op:     "vrapi_SubmitFrame2",
+			result: OVRError_NotReady,
+		},
+		{
+			name:   "status",
+			err:    &Error{Op: "vrapi_Initialize", Code: INITIALIZE_DEVICE_NOT_SUPPORTED},
+			op:     "vrapi_Initialize",
+			status: INITIALIZE_DEVICE_NOT_SUPPORTED,
+		},
+		{
+			name: "not an Error",
+			err:  ErrLeftVrMode,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var e *Error
+			if ok := errors.As(test.err, &e); ok != (test.op != "") {
+				t.Fatalf("errors.As(%v, *Error) = %v", test.err, ok)
+			}
+			if e != nil && e.Op != test.op {
+				t.Errorf("Op is %q, want %q", e.Op, test.op)
+			}
+
+			var result OVRResult
+			if ok := errors.As(test.err, &result); ok != (test.result != 0) || result != test.result {
+				t.Errorf("errors.As(%v, OVRResult) = %v, %v, want %v", test.err, ok, result,
+					test.result)
+			}
+			var status OVRInitializeStatus
+			if ok := errors.As(test.err, &status); ok != (test.status != 0) || status != test.status {
+				t.Errorf("errors.As(%v, OVRInitializeStatus) = %v, %v, want %v", test.err, ok,
+					status, test.status)
+			}
+		})
+	}
+}
+
+func TestErrorString(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{
+			&Error{Op: "vrapi_SubmitFrame2", Code: OVRError_DeviceUnavailable},
+			"vrapi_SubmitFrame2 failed with ovrError_DeviceUnavailable",
+		},
+		{
+			&Error{Op: "vrapi_Initialize", Code: INITIALIZE_SERVICE_CONNECTION_FAILED},
+			"vrapi_Initialize failed with VRAPI_INITIALIZE_SERVICE_CONNECTION_FAILED",
+		},
+		{
+			&Error{Op: "vrapi_GetSystemStatusInt", Code: OVRResult(-1234)},
+			"vrapi_GetSystemStatusInt failed with ovrResult(-1234)",
+		},
+		{
+			&Error{Op: "vrapi_Initialize", Code: OVRInitializeStatus(-7)},
+			"vrapi_Initialize failed with ovrInitializeStatus(-7)",
+		},
+		{OVRSuccess_BoundaryInvalid, "ovrSuccess_BoundaryInvalid"},
+		{INITIALIZE_ALREADY_INITIALIZED, "VRAPI_INITIALIZE_ALREADY_INITIALIZED"},
+	}
+	for _, test := range tests {
+		if got := test.err.Error(); got != test.want {
+			t.Errorf("Error() = %q, want %q", got, test.want)
+		}
+	}
+}
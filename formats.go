@@ -0,0 +1,112 @@
+package vrapi
+
+import "fmt"
+
+// SwapChainFormat is the GL internal format swap chains are created with, or
+// the VkFormat with the Vulkan system. Not every device supports every
+// format, check against GetSupportedSwapChainFormats.
+type SwapChainFormat int64
+
+// Values from GLES3/gl3.h.
+const (
+	// Color formats.
+	GL_RGBA8          SwapChainFormat = 0x8058
+	GL_RGB8           SwapChainFormat = 0x8051
+	GL_SRGB8_ALPHA8   SwapChainFormat = 0x8C43
+	GL_SRGB8          SwapChainFormat = 0x8C41
+	GL_RGB10_A2       SwapChainFormat = 0x8059
+	GL_R11F_G11F_B10F SwapChainFormat = 0x8C3A
+	GL_RGBA16F        SwapChainFormat = 0x881A
+	GL_RGB565         SwapChainFormat = 0x8D62
+	GL_RGBA4          SwapChainFormat = 0x8056
+	GL_RGB5_A1        SwapChainFormat = 0x8057
+
+	// Depth / stencil formats, use with SWAPCHAIN_USAGE_DEPTH_STENCIL_ATTACHMENT_BIT.
+	GL_DEPTH_COMPONENT16  SwapChainFormat = 0x81A5
+	GL_DEPTH_COMPONENT24  SwapChainFormat = 0x81A6
+	GL_DEPTH_COMPONENT32F SwapChainFormat = 0x8CAC
+	GL_DEPTH24_STENCIL8   SwapChainFormat = 0x88F0
+	GL_DEPTH32F_STENCIL8  SwapChainFormat = 0x8CAD
+	GL_STENCIL_INDEX8     SwapChainFormat = 0x8D48
+)
+
+// Depth / stencil VkFormat values from vulkan_core.h, for swap chains of the
+// Vulkan system.
+const (
+	VK_FORMAT_D16_UNORM           SwapChainFormat = 124
+	VK_FORMAT_X8_D24_UNORM_PACK32 SwapChainFormat = 125
+	VK_FORMAT_D32_SFLOAT          SwapChainFormat = 126
+	VK_FORMAT_S8_UINT             SwapChainFormat = 127
+	VK_FORMAT_D16_UNORM_S8_UINT   SwapChainFormat = 128
+	VK_FORMAT_D24_UNORM_S8_UINT   SwapChainFormat = 129
+	VK_FORMAT_D32_SFLOAT_S8_UINT  SwapChainFormat = 130
+)
+
+var swapChainFormatNames = map[SwapChainFormat]string{
+	GL_RGBA8:              "GL_RGBA8",
+	GL_RGB8:               "GL_RGB8",
+	GL_SRGB8_ALPHA8:       "GL_SRGB8_ALPHA8",
+	GL_SRGB8:              "GL_SRGB8",
+	GL_RGB10_A2:           "GL_RGB10_A2",
+	GL_R11F_G11F_B10F:     "GL_R11F_G11F_B10F",
+	GL_RGBA16F:            "GL_RGBA16F",
+	GL_RGB565:             "GL_RGB565",
+	GL_RGBA4:              "GL_RGBA4",
+	GL_RGB5_A1:            "GL_RGB5_A1",
+	GL_DEPTH_COMPONENT16:  "GL_DEPTH_COMPONENT16",
+	GL_DEPTH_COMPONENT24:  "GL_DEPTH_COMPONENT24",
+	GL_DEPTH_COMPONENT32F: "GL_DEPTH_COMPONENT32F",
+	GL_DEPTH24_STENCIL8:   "GL_DEPTH24_STENCIL8",
+	GL_DEPTH32F_STENCIL8:  "GL_DEPTH32F_STENCIL8",
+	GL_STENCIL_INDEX8:     "GL_STENCIL_INDEX8",
+
+	VK_FORMAT_D16_UNORM:           "VK_FORMAT_D16_UNORM",
+	VK_FORMAT_X8_D24_UNORM_PACK32: "VK_FORMAT_X8_D24_UNORM_PACK32",
+	VK_FORMAT_D32_SFLOAT:          "VK_FORMAT_D32_SFLOAT",
+	VK_FORMAT_S8_UINT:             "VK_FORMAT_S8_UINT",
+	VK_FORMAT_D16_UNORM_S8_UINT:   "VK_FORMAT_D16_UNORM_S8_UINT",
+	VK_FORMAT_D24_UNORM_S8_UINT:   "VK_FORMAT_D24_UNORM_S8_UINT",
+	VK_FORMAT_D32_SFLOAT_S8_UINT:  "VK_FORMAT_D32_SFLOAT_S8_UINT",
+}
+
+func (f SwapChainFormat) String() string {
+	if name, ok := swapChainFormatNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("SwapChainFormat(0x%X)", int64(f))
+}
+
+func (f SwapChainFormat) IsSRGB() bool {
+	return f == GL_SRGB8_ALPHA8 || f == GL_SRGB8
+}
+
+func (f SwapChainFormat) IsDepth() bool {
+	switch f {
+	case GL_DEPTH_COMPONENT16, GL_DEPTH_COMPONENT24, GL_DEPTH_COMPONENT32F,
+		GL_DEPTH24_STENCIL8, GL_DEPTH32F_STENCIL8, GL_STENCIL_INDEX8:
+		return true
+	case VK_FORMAT_D16_UNORM, VK_FORMAT_X8_D24_UNORM_PACK32, VK_FORMAT_D32_SFLOAT,
+		VK_FORMAT_S8_UINT, VK_FORMAT_D16_UNORM_S8_UINT, VK_FORMAT_D24_UNORM_S8_UINT,
+		VK_FORMAT_D32_SFLOAT_S8_UINT:
+		return true
+	}
+	return false
+}
+
+// srgbColorFormats in order of preference. Alpha first since the compositor
+// blends layers with it.
+var srgbColorFormats = []SwapChainFormat{GL_SRGB8_ALPHA8, GL_SRGB8}
+
+// BestSRGBColorFormat picks the preferred sRGB color format out of supported,
+// usually from GetSupportedSwapChainFormats. ok is false if none of them are
+// sRGB.
+func BestSRGBColorFormat(supported []SwapChainFormat) (format SwapChainFormat, ok bool) {
+	for _, want := range srgbColorFormats {
+		for _, f := range supported {
+			if f == want {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,59 @@
+package vrapi
+
+import "testing"
+
+func TestBestSRGBColorFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported []SwapChainFormat
+		format    SwapChainFormat
+		ok        bool
+	}{
+		{"none", nil, 0, false},
+		{"no srgb", []SwapChainFormat{GL_RGBA8, GL_RGB565, GL_DEPTH_COMPONENT24}, 0, false},
+		{"only without alpha", []SwapChainFormat{GL_RGBA8, GL_SRGB8}, GL_SRGB8, true},
+		{"alpha preferred", []SwapChainFormat{GL_SRGB8, GL_RGBA8, GL_SRGB8_ALPHA8},
+			GL_SRGB8_ALPHA8, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format, ok := BestSRGBColorFormat(test.supported)
+			if format != test.format || ok != test.ok {
+				t.Errorf("BestSRGBColorFormat(%v) = %v, %v, want %v, %v", test.supported,
+					format, ok, test.format, test.ok)
+			}
+		})
+	}
+}
+
+func TestSwapChainFormat(t *testing.T) {
+	tests := []struct {
+		format SwapChainFormat
+		name   string
+		srgb   bool
+		depth  bool
+	}{
+		{GL_RGBA8, "GL_RGBA8", false, false},
+		{GL_SRGB8_ALPHA8, "GL_SRGB8_ALPHA8", true, false},
+		{GL_SRGB8, "GL_SRGB8", true, false},
+		{GL_RGBA16F, "GL_RGBA16F", false, false},
+		{GL_DEPTH_COMPONENT16, "GL_DEPTH_COMPONENT16", false, true},
+		{GL_DEPTH24_STENCIL8, "GL_DEPTH24_STENCIL8", false, true},
+		{GL_STENCIL_INDEX8, "GL_STENCIL_INDEX8", false, true},
+		{VK_FORMAT_D16_UNORM, "VK_FORMAT_D16_UNORM", false, true},
+		{VK_FORMAT_X8_D24_UNORM_PACK32, "VK_FORMAT_X8_D24_UNORM_PACK32", false, true},
+		{VK_FORMAT_D32_SFLOAT_S8_UINT, "VK_FORMAT_D32_SFLOAT_S8_UINT", false, true},
+		{SwapChainFormat(0x1234), "SwapChainFormat(0x1234)", false, false},
+	}
+	for _, test := range tests {
+		if name := test.format.String(); name != test.name {
+			t.Errorf("String() = %q, want %q", name, test.name)
+		}
+		if srgb := test.format.IsSRGB(); srgb != test.srgb {
+			t.Errorf("%v.IsSRGB() = %v, want %v", test.format, srgb, test.srgb)
+		}
+		if depth := test.format.IsDepth(); depth != test.depth {
+			t.Errorf("%v.IsDepth() = %v, want %v", test.format, depth, test.depth)
+		}
+	}
+}
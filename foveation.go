@@ -0,0 +1,128 @@
+package vrapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFoveationUnavailable is returned when the device does not support fixed
+// foveated rendering, see SYS_PROP_FOVEATION_AVAILABLE.
+var ErrFoveationUnavailable = errors.New("foveated rendering is not available")
+
+// FoveationLevel is the value of the FOVEATION_LEVEL property. Higher levels
+// render the periphery of the eye buffers at a lower resolution.
+type FoveationLevel int
+
+const (
+	FoveationNone FoveationLevel = iota
+	FoveationLow
+	FoveationMedium
+	FoveationHigh
+	FoveationHighTop // Like FoveationHigh but also reduces the top of the view.
+)
+
+func (l FoveationLevel) String() string {
+	switch l {
+	case FoveationNone:
+		return "None"
+	case FoveationLow:
+		return "Low"
+	case FoveationMedium:
+		return "Medium"
+	case FoveationHigh:
+		return "High"
+	case FoveationHighTop:
+		return "HighTop"
+	}
+	return fmt.Sprintf("FoveationLevel(%d)", int(l))
+}
+
+// FoveationAvailable reports SYS_PROP_FOVEATION_AVAILABLE. Initialize must
+// have been called first.
+func (c *Context) FoveationAvailable() (bool, error) {
+	var available bool
+	err := c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+		available = c.state.foveationAvailable()
+		return nil
+	})
+
+	return available, err
+}
+
+// SetFoveationLevel sets the level used for swapChain, which is normally the
+// eye buffer swap chain. The level is a global property so it applies to
+// every GL color swap chain, the swap chain is only used to check the level
+// is being set on something that can be foveated: it must be live and be a
+// color swap chain, depth and default swap chains are rejected. Vulkan swap
+// chains are foveated through GetTextureSwapChainBufferFoveationVulkan
+// instead.
+func (c *Context) SetFoveationLevel(swapChain OVRTextureSwapChain, level FoveationLevel) error {
+	return c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+		if level < FoveationNone || level > FoveationHighTop {
+			return fmt.Errorf("foveation level %d out of range", int(level))
+		}
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+		if format := c.state.swapChains[swapChain]; format.IsDepth() {
+			return fmt.Errorf("texture swap chain %#x has depth format %v and can not be foveated",
+				swapChain, format)
+		}
+		if !c.state.foveationAvailable() {
+			return ErrFoveationUnavailable
+		}
+
+		SetPropertyInt(c.state.java, FOVEATION_LEVEL, int(level))
+		return nil
+	})
+}
+
+// FoveationLevel returns the current FOVEATION_LEVEL. With dynamic foveation
+// enabled this is the maximum level the runtime may pick.
+func (c *Context) FoveationLevel() (FoveationLevel, error) {
+	var level FoveationLevel
+	err := c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+
+		val, ok := GetPropertyInt(c.state.java, FOVEATION_LEVEL)
+		if !ok {
+			return errors.New("vrapi_GetPropertyInt failed to read FOVEATION_LEVEL")
+		}
+		level = FoveationLevel(val)
+		return nil
+	})
+
+	return level, err
+}
+
+// SetDynamicFoveation lets the runtime lower the foveation level, up to the
+// level from SetFoveationLevel, when the GPU has time to spare.
+func (c *Context) SetDynamicFoveation(enabled bool) error {
+	return c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+		if !c.state.foveationAvailable() {
+			return ErrFoveationUnavailable
+		}
+
+		val := 0
+		if enabled {
+			val = 1
+		}
+		SetPropertyInt(c.state.java, DYNAMIC_FOVEATION_ENABLED, val)
+		return nil
+	})
+}
+
+func (s *contextState) foveationAvailable() bool {
+	return GetSystemPropertyInt(s.java, SYS_PROP_FOVEATION_AVAILABLE) != 0
+}
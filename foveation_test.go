@@ -0,0 +1,76 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestFoveation(t *testing.T) {
+	c := newTestContext(t)
+	color := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D_ARRAY, GL_SRGB8_ALPHA8, 64, 64, 1, 3)
+
+	if available, err := c.FoveationAvailable(); err != nil || available {
+		t.Errorf("FoveationAvailable() = %v, %v, want false", available, err)
+	}
+	if err := c.SetFoveationLevel(color, FoveationHigh); !errors.Is(err, ErrFoveationUnavailable) {
+		t.Errorf("SetFoveationLevel while unavailable returned %v", err)
+	}
+	if err := c.SetDynamicFoveation(true); !errors.Is(err, ErrFoveationUnavailable) {
+		t.Errorf("SetDynamicFoveation while unavailable returned %v", err)
+	}
+
+	fakevrapi.SetFoveationAvailable(true)
+	if available, err := c.FoveationAvailable(); err != nil || !available {
+		t.Errorf("FoveationAvailable() = %v, %v, want true", available, err)
+	}
+	if err := c.SetFoveationLevel(color, FoveationHigh); err != nil {
+		t.Errorf("SetFoveationLevel: %v", err)
+	}
+	if err := c.SetDynamicFoveation(true); err != nil {
+		t.Errorf("SetDynamicFoveation: %v", err)
+	}
+	if state := fakevrapi.GetState(); state.FoveationLevel != int(FoveationHigh) ||
+		!state.DynamicFoveation {
+		t.Errorf("runtime has foveation level %d and dynamic foveation %v, want %d and true",
+			state.FoveationLevel, state.DynamicFoveation, FoveationHigh)
+	}
+	if level, err := c.FoveationLevel(); err != nil || level != FoveationHigh {
+		t.Errorf("FoveationLevel() = %v, %v, want High", level, err)
+	}
+
+	depth := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D_ARRAY, GL_DEPTH_COMPONENT24, 64, 64, 1, 3)
+	vulkanDepth := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D_ARRAY, VK_FORMAT_D32_SFLOAT, 64, 64, 1, 3)
+	destroyed := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D_ARRAY, GL_RGBA8, 64, 64, 1, 3)
+	if err := c.DestroyTextureSwapChain(destroyed); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		swapChain OVRTextureSwapChain
+		level     FoveationLevel
+	}{
+		{"level below None", color, FoveationNone - 1},
+		{"level above HighTop", color, FoveationHighTop + 1},
+		{"default swap chain", DEFAULT_TEXTURE_SWAPCHAIN, FoveationLow},
+		{"destroyed swap chain", destroyed, FoveationLow},
+		{"depth swap chain", depth, FoveationLow},
+		{"vulkan depth swap chain", vulkanDepth, FoveationLow},
+	}
+	for _, test := range tests {
+		if err := c.SetFoveationLevel(test.swapChain, test.level); err == nil {
+			t.Errorf("SetFoveationLevel on %s succeeded", test.name)
+		}
+	}
+	if level := fakevrapi.GetState().FoveationLevel; level != int(FoveationHigh) {
+		t.Errorf("rejected calls changed the foveation level to %d", level)
+	}
+
+	c.Shutdown()
+	if _, err := c.FoveationLevel(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("FoveationLevel after Shutdown returned %v, want ErrNotInitialized", err)
+	}
+}
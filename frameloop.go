@@ -0,0 +1,225 @@
+package vrapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi/ovrMatrix4f"
+)
+
+// Frame is handed to the FrameLoop's render callback once per frame.
+type Frame struct {
+	Index       int64
+	DisplayTime float64 // Predicted display time in seconds.
+	Tracking    OVRTracking2
+	Eyes        [FRAME_LAYER_EYE_MAX]FrameEye
+
+	// Projection is built from Tracking and Eyes and is always the first of
+	// Layers. Append to Layers to submit more layers (cylinders, loading
+	// icons, ...) on top of it.
+	Projection *OVRLayerProjection2
+	Layers     []Layer
+
+	// The FrameLoop's Context bound to the Worker while Render runs, see
+	// Context.DoBound. Render calls back into the Context through this one.
+	Context *Context
+}
+
+type FrameEye struct {
+	// View and projection matrices already transposed for mgl.
+	View       mgl.Mat4
+	Projection mgl.Mat4
+
+	// Render into image SwapChainIndex of SwapChain this frame.
+	SwapChain      OVRTextureSwapChain
+	SwapChainIndex int
+	// GL texture of that image, looked up by NewFrameLoop so Render does not
+	// need to call GetTextureSwapChainHandle.
+	TextureHandle uint32
+}
+
+// FrameStats is reported to FrameLoop.OnStats after each submitted frame.
+type FrameStats struct {
+	Index       int64
+	DisplayTime float64
+	Start       time.Time
+	// Time since the previous frame started, zero for the first frame.
+	Interval time.Duration
+	Render   time.Duration
+	Submit   time.Duration
+}
+
+// FrameLoop runs the usual vrapi frame: bump the frame index, predict the
+// display time and tracking, render each eye, then submit the layers.
+type FrameLoop struct {
+	// Called on the Worker so GL calls are safe to make from it, as are
+	// calls to frame.Context.
+	Render func(frame *Frame) error
+	// Optional, called after every submitted frame.
+	OnStats func(stats FrameStats)
+	// Defaults to time.Now, swap out for a fake clock in tests.
+	Now func() time.Time
+
+	ctx             *Context
+	vrApp           *OVRMobile
+	swapChains      [FRAME_LAYER_EYE_MAX]OVRTextureSwapChain
+	swapChainLength int
+	// GL texture of each image of each eye's swap chain.
+	textureHandles [FRAME_LAYER_EYE_MAX][]uint32
+
+	frameIndex     int64
+	swapChainIndex int
+	lastStart      time.Time
+
+	frame      Frame
+	projection OVRLayerProjection2
+}
+
+// NewFrameLoop renders each eye into swapChains[eye]. The same swap chain can
+// be passed for both eyes (e.g. a TEXTURE_TYPE_2D_ARRAY for multiview),
+// otherwise both must have the same length so they rotate together.
+func NewFrameLoop(ctx *Context, vrApp *OVRMobile,
+	swapChains [FRAME_LAYER_EYE_MAX]OVRTextureSwapChain,
+	render func(frame *Frame) error) (*FrameLoop, error) {
+
+	var lengths [FRAME_LAYER_EYE_MAX]int
+	var textureHandles [FRAME_LAYER_EYE_MAX][]uint32
+	for eye, swapChain := range swapChains {
+		length, err := ctx.GetTextureSwapChainLength(swapChain)
+		if err != nil {
+			return nil, err
+		}
+		if length <= 0 {
+			return nil, fmt.Errorf("swap chain length %d must be positive", length)
+		}
+		lengths[eye] = length
+
+		for i := 0; i < length; i++ {
+			handle, err := ctx.GetTextureSwapChainHandle(swapChain, i)
+			if err != nil {
+				return nil, err
+			}
+			textureHandles[eye] = append(textureHandles[eye], handle)
+		}
+	}
+	for eye, length := range lengths {
+		if length != lengths[0] {
+			return nil, fmt.Errorf("eye %d swap chain length %d does not match eye 0 length %d",
+				eye, length, lengths[0])
+		}
+	}
+
+	return &FrameLoop{
+		Render:          render,
+		Now:             time.Now,
+		ctx:             ctx,
+		vrApp:           vrApp,
+		swapChains:      swapChains,
+		swapChainLength: lengths[0],
+		textureHandles:  textureHandles,
+	}, nil
+}
+
+// FrameIndex returns the index of the last frame started.
+func (l *FrameLoop) FrameIndex() int64 {
+	return l.frameIndex
+}
+
+// Run calls Frame until ctx is done or a frame fails.
+func (l *FrameLoop) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := l.Frame(); err != nil {
+			return err
+		}
+	}
+}
+
+// Frame renders and submits a single frame.
+func (l *FrameLoop) Frame() error {
+	start := l.Now()
+
+	l.frameIndex++
+	displayTime := GetPredictedDisplayTime(l.vrApp, l.frameIndex)
+	tracking := GetPredictedTracking2(l.vrApp, displayTime)
+
+	l.projection = DefaultLayerProjection2()
+	l.projection.HeadPose = tracking.HeadPose
+
+	f := &l.frame
+	*f = Frame{
+		Index:       l.frameIndex,
+		DisplayTime: displayTime,
+		Tracking:    tracking,
+		Projection:  &l.projection,
+		Layers:      append(f.Layers[:0], &l.projection),
+	}
+	for eye := range f.Eyes {
+		f.Eyes[eye] = FrameEye{
+			View:           tracking.Eye[eye].ViewMatrix.Transpose(),
+			Projection:     tracking.Eye[eye].ProjectionMatrix.Transpose(),
+			SwapChain:      l.swapChains[eye],
+			SwapChainIndex: l.swapChainIndex,
+			TextureHandle:  l.textureHandles[eye][l.swapChainIndex],
+		}
+
+		l.projection.Textures[eye].ColorSwapChain = l.swapChains[eye]
+		l.projection.Textures[eye].SwapChainIndex = int32(l.swapChainIndex)
+		l.projection.Textures[eye].TexCoordsFromTanAngles =
+			ovrMatrix4f.TanAngleMatrixFromProjection(&tracking.Eye[eye].ProjectionMatrix)
+	}
+
+	renderStart := l.Now()
+	var renderErr error
+	err := l.ctx.DoBound(func(bound *Context) {
+		f.Context = bound
+		renderErr = l.Render(f)
+		f.Context = nil
+	})
+	if err != nil {
+		return err
+	}
+	if renderErr != nil {
+		return renderErr
+	}
+	rendered := l.Now()
+
+	frameDesc := OVRSubmitFrameDescription2{
+		SwapInterval: 1,
+		FrameIndex:   uint64(l.frameIndex),
+		DisplayTime:  displayTime,
+		LayerCount:   uint32(len(f.Layers)),
+		Layers:       f.Layers,
+	}
+	if err := l.ctx.SubmitFrame2(l.vrApp, &frameDesc); err != nil {
+		return err
+	}
+	submitted := l.Now()
+
+	l.swapChainIndex = (l.swapChainIndex + 1) % l.swapChainLength
+
+	if l.OnStats != nil {
+		stats := FrameStats{
+			Index:       l.frameIndex,
+			DisplayTime: displayTime,
+			Start:       start,
+			Render:      rendered.Sub(renderStart),
+			Submit:      submitted.Sub(rendered),
+		}
+		if !l.lastStart.IsZero() {
+			stats.Interval = start.Sub(l.lastStart)
+		}
+		l.OnStats(stats)
+	}
+	l.lastStart = start
+
+	return nil
+}
@@ -0,0 +1,155 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+// fakeClock moves forward by step every time it is read.
+type fakeClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+func TestFrameLoop(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	const length = 3
+	var swapChains [FRAME_LAYER_EYE_MAX]OVRTextureSwapChain
+	for eye := range swapChains {
+		swapChains[eye] = c.CreateTextureSwapChain3(TEXTURE_TYPE_2D,
+			SwapChainFormat(0x8058), 64, 64, 1, length)
+		defer c.DestroyTextureSwapChain(swapChains[eye])
+	}
+
+	clock := &fakeClock{now: time.Unix(0, 0), step: time.Millisecond}
+	const renderTime = 5 * time.Millisecond
+
+	var frames []Frame
+	render := func(frame *Frame) error {
+		frames = append(frames, *frame)
+		clock.now = clock.now.Add(renderTime)
+
+		// Render runs on the Worker, calling back into the Context through
+		// the bound one must not deadlock.
+		_, err := frame.Context.GetTextureSwapChainHandle(frame.Eyes[0].SwapChain, frame.Eyes[0].SwapChainIndex)
+		return err
+	}
+	loop, err := NewFrameLoop(c, vrApp, swapChains, render)
+	if err != nil {
+		t.Fatalf("NewFrameLoop: %v", err)
+	}
+	loop.Now = clock.Now
+	var stats []FrameStats
+	loop.OnStats = func(s FrameStats) { stats = append(stats, s) }
+
+	const n = 5
+	for i := 1; i <= n; i++ {
+		if err := loop.Frame(); err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+
+		submit := fakevrapi.LastSubmit()
+		if submit.FrameIndex != uint64(i) || submit.DisplayTime != fakevrapi.PredictedDisplayTime(int64(i)) {
+			t.Errorf("frame %d submitted as frame %d at %v", i, submit.FrameIndex, submit.DisplayTime)
+		}
+		if len(submit.Layers) != 1 || submit.Layers[0].Type != int(LAYER_TYPE_PROJECTION2) {
+			t.Fatalf("frame %d submitted %+v, want a single projection layer", i, submit.Layers)
+		}
+		var projection OVRLayerProjection2
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&projection)), unsafe.Sizeof(projection)),
+			submit.Layers[0].Bytes)
+		for eye, texture := range projection.Textures {
+			if texture.ColorSwapChain != swapChains[eye] || texture.SwapChainIndex != int32((i-1)%length) {
+				t.Errorf("frame %d eye %d submitted image %d of %#x", i, eye,
+					texture.SwapChainIndex, texture.ColorSwapChain)
+			}
+		}
+	}
+	if loop.FrameIndex() != n {
+		t.Errorf("FrameIndex %d, want %d", loop.FrameIndex(), n)
+	}
+
+	if len(frames) != n {
+		t.Fatalf("rendered %d frames, want %d", len(frames), n)
+	}
+	for i, frame := range frames {
+		index := int64(i + 1)
+		displayTime := fakevrapi.PredictedDisplayTime(index)
+		if frame.Index != index || frame.DisplayTime != displayTime {
+			t.Errorf("frame %d: index %d display time %v, want %v", i, frame.Index,
+				frame.DisplayTime, displayTime)
+		}
+		if frame.Tracking.HeadPose.TimeInSeconds != displayTime {
+			t.Errorf("frame %d: tracking predicted for %v, want %v", i,
+				frame.Tracking.HeadPose.TimeInSeconds, displayTime)
+		}
+		for eye, frameEye := range frame.Eyes {
+			swapChainIndex := i % length
+			handle := fakevrapi.SwapChainHandle(uintptr(swapChains[eye]), swapChainIndex)
+			if frameEye.SwapChain != swapChains[eye] || frameEye.SwapChainIndex != swapChainIndex ||
+				frameEye.TextureHandle != handle {
+				t.Errorf("frame %d eye %d: image %d of %#x handle %d, want image %d of %#x handle %d",
+					i, eye, frameEye.SwapChainIndex, frameEye.SwapChain, frameEye.TextureHandle,
+					swapChainIndex, swapChains[eye], handle)
+			}
+			// The fake offsets the eyes' row major view matrices, which
+			// transposed for mgl ends up in the last column.
+			offset := frame.Tracking.Eye[eye].ViewMatrix[3]
+			if offset == 0 || frameEye.View[12] != offset || frameEye.View[3] != 0 {
+				t.Errorf("frame %d eye %d: view matrix not transposed", i, eye)
+			}
+		}
+	}
+
+	// Start, render start, rendered and submitted are each a tick of the
+	// clock, plus the time spent rendering.
+	frameTime := 4*clock.step + renderTime
+	for i, s := range stats {
+		var interval time.Duration
+		if i > 0 {
+			interval = frameTime
+		}
+		if s.Index != int64(i+1) || s.Interval != interval ||
+			s.Render != clock.step+renderTime || s.Submit != clock.step {
+			t.Errorf("frame %d stats %+v", i, s)
+		}
+		if want := time.Unix(0, 0).Add(clock.step + time.Duration(i)*frameTime); !s.Start.Equal(want) {
+			t.Errorf("frame %d started at %v, want %v", i, s.Start, want)
+		}
+	}
+}
+
+func TestFrameLoopSwapChainLengths(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	left := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D, SwapChainFormat(0x8058), 64, 64, 1, 3)
+	defer c.DestroyTextureSwapChain(left)
+	right := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D, SwapChainFormat(0x8058), 64, 64, 1, 2)
+	defer c.DestroyTextureSwapChain(right)
+
+	swapChains := [FRAME_LAYER_EYE_MAX]OVRTextureSwapChain{left, right}
+	if _, err := NewFrameLoop(c, vrApp, swapChains, nil); err == nil {
+		t.Error("NewFrameLoop with swap chains of different lengths returned nil error")
+	}
+
+	// One swap chain for both eyes.
+	swapChains = [FRAME_LAYER_EYE_MAX]OVRTextureSwapChain{left, left}
+	if _, err := NewFrameLoop(c, vrApp, swapChains, nil); err != nil {
+		t.Errorf("NewFrameLoop with the same swap chain for both eyes: %v", err)
+	}
+}
@@ -0,0 +1,362 @@
+// Package gesture turns vrapi hand input into pinch and hand gesture events.
+//
+// A Recognizer is fed one Frame per rendered frame for a single hand. It
+// never talks to the runtime itself so recorded Frames can be replayed
+// through it without a device.
+package gesture
+
+import (
+	"fmt"
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi"
+)
+
+type EventType int
+
+const (
+	PinchBegin EventType = iota
+	PinchHold            // Sent every frame between PinchBegin and PinchEnd.
+	PinchEnd
+	GestureBegin
+	GestureEnd
+)
+
+func (t EventType) String() string {
+	switch t {
+	case PinchBegin:
+		return "PinchBegin"
+	case PinchHold:
+		return "PinchHold"
+	case PinchEnd:
+		return "PinchEnd"
+	case GestureBegin:
+		return "GestureBegin"
+	case GestureEnd:
+		return "GestureEnd"
+	}
+	return fmt.Sprintf("EventType(%d)", int(t))
+}
+
+type Gesture int
+
+const (
+	GestureNone Gesture = iota
+	// Index, middle, ring and pinky extended, the thumb is not checked.
+	GestureOpenPalm
+	// Index extended, middle, ring and pinky curled.
+	GesturePoint
+)
+
+func (g Gesture) String() string {
+	switch g {
+	case GestureNone:
+		return "None"
+	case GestureOpenPalm:
+		return "OpenPalm"
+	case GesturePoint:
+		return "Point"
+	}
+	return fmt.Sprintf("Gesture(%d)", int(g))
+}
+
+type Event struct {
+	Type EventType
+	Time float64 // Header.TimeInSeconds of the Frame that caused the event.
+
+	// Pinch events only.
+	Finger   vrapi.OVRHandPinchStrength
+	Strength float32
+
+	// Gesture events only.
+	Gesture Gesture
+}
+
+// Frame is the hand input for one frame.
+type Frame struct {
+	// From vrapi.GetHandState.
+	State vrapi.OVRInputStateHand
+	// From vrapi.GetHandPose. Optional, without it finger confidences are
+	// not checked and no gestures are recognised.
+	Pose *vrapi.HandPose
+	// The hand's OVRControllerType_StandardPointer state, the aim pose is
+	// taken from it. Optional.
+	Pointer *vrapi.OVRInputStateStandardPointer
+}
+
+// Config tunes a Recognizer. The zero value of a field picks the default from
+// DefaultConfig.
+type Config struct {
+	// A pinch begins once its strength reaches PinchBegin and ends once it
+	// falls to PinchEnd. PinchEnd < PinchBegin so a strength hovering around
+	// one threshold does not flicker.
+	PinchBegin float32
+	PinchEnd   float32
+
+	// How long, in seconds, a pinch or gesture has to be held (or released)
+	// before it is reported. Set NoDebounce to report changes on the frame
+	// they happen, a zero Debounce picks the default.
+	Debounce   float64
+	NoDebounce bool
+
+	// Total bend in radians of a finger's joints below which the finger
+	// counts as extended and above which it counts as curled.
+	ExtendedCurl float32
+	CurledCurl   float32
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PinchBegin:   0.9,
+		PinchEnd:     0.6,
+		Debounce:     0.03,
+		ExtendedCurl: 0.6,
+		CurledCurl:   1.8,
+	}
+}
+
+// debouncer only flips once the raw value held for the debounce time.
+type debouncer struct {
+	value        bool
+	pending      bool
+	pendingSince float64
+}
+
+func (d *debouncer) update(raw bool, now, debounce float64) (changed bool) {
+	if raw == d.value {
+		d.pending = d.value
+		return false
+	}
+	if raw != d.pending {
+		d.pending = raw
+		d.pendingSince = now
+	}
+	if now-d.pendingSince < debounce {
+		return false
+	}
+
+	d.value = raw
+	return true
+}
+
+type pinch struct {
+	debouncer
+	strength float32
+}
+
+// Recognizer tracks a single hand.
+type Recognizer struct {
+	config Config
+
+	pinches [vrapi.OVRHand_MaxPinchStrengths]pinch
+
+	gesture        Gesture
+	pendingGesture Gesture
+	pendingSince   float64
+
+	aim      vrapi.OVRPosef
+	aimValid bool
+}
+
+func NewRecognizer(config Config) *Recognizer {
+	defaults := DefaultConfig()
+	if config.PinchBegin == 0 {
+		config.PinchBegin = defaults.PinchBegin
+	}
+	if config.PinchEnd == 0 {
+		config.PinchEnd = defaults.PinchEnd
+	}
+	if config.NoDebounce {
+		config.Debounce = 0
+	} else if config.Debounce == 0 {
+		config.Debounce = defaults.Debounce
+	}
+	if config.ExtendedCurl == 0 {
+		config.ExtendedCurl = defaults.ExtendedCurl
+	}
+	if config.CurledCurl == 0 {
+		config.CurledCurl = defaults.CurledCurl
+	}
+
+	return &Recognizer{config: config}
+}
+
+// Update feeds the next frame and returns the events it caused.
+func (r *Recognizer) Update(frame *Frame) []Event {
+	var events []Event
+	now := frame.State.Header.TimeInSeconds
+	tracked := frame.Pose == nil || frame.Pose.Status == vrapi.OVRHandTrackingStatus_Tracked
+
+	r.aimValid = frame.Pointer != nil && tracked &&
+		frame.Pointer.InputStateStatus&vrapi.OVRInputStateStandardPointerStatus_PointerValid != 0
+	if r.aimValid {
+		r.aim = frame.Pointer.PointerPose
+	}
+
+	for i := range r.pinches {
+		finger := vrapi.OVRHandPinchStrength(i)
+		events = r.updatePinch(events, finger, frame, tracked, now)
+	}
+
+	gesture := GestureNone
+	if tracked && frame.Pose != nil {
+		gesture = r.classify(frame.Pose)
+	}
+	events = r.updateGesture(events, gesture, now)
+
+	return events
+}
+
+func (r *Recognizer) updatePinch(events []Event, finger vrapi.OVRHandPinchStrength,
+	frame *Frame, tracked bool, now float64) []Event {
+
+	p := &r.pinches[finger]
+	strength := frame.State.PinchStrength[finger]
+
+	// PointerValid is not checked, it only says whether the pointer pose can
+	// be used and the fingers can still be tracked without it.
+	raw := p.value
+	switch {
+	case !tracked:
+		// Lost the hand, end right away rather than waiting out the debounce.
+		if p.value {
+			p.debouncer = debouncer{}
+			return append(events, Event{Type: PinchEnd, Time: now, Finger: finger,
+				Strength: p.strength})
+		}
+		return events
+	case !confident(frame.Pose, finger):
+		// Keep whatever state the pinch was in until the fingers can be seen.
+	case strength >= r.config.PinchBegin:
+		raw = true
+	case strength <= r.config.PinchEnd:
+		raw = false
+	}
+
+	p.strength = strength
+	if p.update(raw, now, r.config.Debounce) {
+		eventType := PinchEnd
+		if p.value {
+			eventType = PinchBegin
+		}
+		return append(events, Event{Type: eventType, Time: now, Finger: finger, Strength: strength})
+	}
+	if p.value {
+		events = append(events, Event{Type: PinchHold, Time: now, Finger: finger, Strength: strength})
+	}
+	return events
+}
+
+// pinchFingers maps each pinch to the finger pinching the thumb.
+var pinchFingers = [vrapi.OVRHand_MaxPinchStrengths]vrapi.OVRHandFinger{
+	vrapi.OVRHandPinchStrength_Index:  vrapi.OVRHandFinger_Index,
+	vrapi.OVRHandPinchStrength_Middle: vrapi.OVRHandFinger_Middle,
+	vrapi.OVRHandPinchStrength_Ring:   vrapi.OVRHandFinger_Ring,
+	vrapi.OVRHandPinchStrength_Pinky:  vrapi.OVRHandFinger_Pinky,
+}
+
+// confident reports whether both fingers of a pinch are tracked with high
+// confidence, always true without a pose.
+func confident(pose *vrapi.HandPose, finger vrapi.OVRHandPinchStrength) bool {
+	if pose == nil {
+		return true
+	}
+	return pose.FingerConfidences[vrapi.OVRHandFinger_Thumb] == vrapi.OVRConfidence_HIGH &&
+		pose.FingerConfidences[pinchFingers[finger]] == vrapi.OVRConfidence_HIGH
+}
+
+func (r *Recognizer) updateGesture(events []Event, gesture Gesture, now float64) []Event {
+	if gesture == r.gesture {
+		r.pendingGesture = gesture
+		return events
+	}
+	if gesture != r.pendingGesture {
+		r.pendingGesture = gesture
+		r.pendingSince = now
+	}
+	if now-r.pendingSince < r.config.Debounce {
+		return events
+	}
+
+	if r.gesture != GestureNone {
+		events = append(events, Event{Type: GestureEnd, Time: now, Gesture: r.gesture})
+	}
+	r.gesture = gesture
+	if gesture != GestureNone {
+		events = append(events, Event{Type: GestureBegin, Time: now, Gesture: gesture})
+	}
+	return events
+}
+
+// fingerBones are the joints of each finger, excluding the thumb, whose
+// local rotations add up to how far the finger is curled.
+var fingerBones = map[vrapi.OVRHandFinger][]vrapi.OVRHandBone{
+	vrapi.OVRHandFinger_Index: {vrapi.OVRHandBone_Index1, vrapi.OVRHandBone_Index2,
+		vrapi.OVRHandBone_Index3},
+	vrapi.OVRHandFinger_Middle: {vrapi.OVRHandBone_Middle1, vrapi.OVRHandBone_Middle2,
+		vrapi.OVRHandBone_Middle3},
+	vrapi.OVRHandFinger_Ring: {vrapi.OVRHandBone_Ring1, vrapi.OVRHandBone_Ring2,
+		vrapi.OVRHandBone_Ring3},
+	vrapi.OVRHandFinger_Pinky: {vrapi.OVRHandBone_Pinky1, vrapi.OVRHandBone_Pinky2,
+		vrapi.OVRHandBone_Pinky3},
+}
+
+// Curl returns the total bend in radians of finger's joints, 0 for a
+// straight finger. The thumb is not supported.
+func Curl(pose *vrapi.HandPose, finger vrapi.OVRHandFinger) float32 {
+	var curl float32
+	for _, bone := range fingerBones[finger] {
+		curl += angle(pose.BoneRotations[bone])
+	}
+	return curl
+}
+
+// angle of rotation of q in radians.
+func angle(q mgl.Quat) float32 {
+	w := math.Abs(float64(q.W))
+	if w > 1 {
+		w = 1
+	}
+	return float32(2 * math.Acos(w))
+}
+
+func (r *Recognizer) classify(pose *vrapi.HandPose) Gesture {
+	if pose.HandConfidence != vrapi.OVRConfidence_HIGH {
+		return GestureNone
+	}
+
+	extended := func(finger vrapi.OVRHandFinger) bool {
+		return Curl(pose, finger) <= r.config.ExtendedCurl
+	}
+	curled := func(finger vrapi.OVRHandFinger) bool {
+		return Curl(pose, finger) >= r.config.CurledCurl
+	}
+
+	switch {
+	case extended(vrapi.OVRHandFinger_Index) && extended(vrapi.OVRHandFinger_Middle) &&
+		extended(vrapi.OVRHandFinger_Ring) && extended(vrapi.OVRHandFinger_Pinky):
+		return GestureOpenPalm
+	case extended(vrapi.OVRHandFinger_Index) && curled(vrapi.OVRHandFinger_Middle) &&
+		curled(vrapi.OVRHandFinger_Ring) && curled(vrapi.OVRHandFinger_Pinky):
+		return GesturePoint
+	}
+	return GestureNone
+}
+
+// Gesture returns the current debounced gesture.
+func (r *Recognizer) Gesture() Gesture {
+	return r.gesture
+}
+
+// Pinching reports whether finger is between PinchBegin and PinchEnd.
+func (r *Recognizer) Pinching(finger vrapi.OVRHandPinchStrength) bool {
+	return r.pinches[finger].value
+}
+
+// Aim returns the pointer pose from the last Frame's StandardPointer state.
+// ok is false when there was none or it was not valid.
+func (r *Recognizer) Aim() (pose vrapi.OVRPosef, ok bool) {
+	return r.aim, r.aimValid
+}
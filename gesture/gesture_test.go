@@ -0,0 +1,327 @@
+//go:build darwin || linux || windows
+
+package gesture
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi"
+	_ "github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+// recorded is one frame of a recorded index finger pinch. With frames 20ms
+// apart the default 30ms debounce takes two frames to pass.
+type recorded struct {
+	time      float64
+	strength  float32
+	untracked bool // Pose.Status is Untracked.
+	noPointer bool // InputStateStatus lacks PointerValid.
+	unsure    bool // Low confidence in the index finger.
+}
+
+func (r recorded) frame() *Frame {
+	frame := &Frame{Pose: &vrapi.HandPose{
+		Status:         vrapi.OVRHandTrackingStatus_Tracked,
+		HandConfidence: vrapi.OVRConfidence_HIGH,
+	}}
+	for i := range frame.Pose.FingerConfidences {
+		frame.Pose.FingerConfidences[i] = vrapi.OVRConfidence_HIGH
+	}
+	for i := range frame.Pose.BoneRotations {
+		frame.Pose.BoneRotations[i] = mgl.QuatIdent()
+	}
+
+	frame.State.Header.TimeInSeconds = r.time
+	frame.State.PinchStrength[vrapi.OVRHandPinchStrength_Index] = r.strength
+	if !r.noPointer {
+		frame.State.InputStateStatus = vrapi.OVRInputStateHandStatus_PointerValid
+	}
+	if r.untracked {
+		frame.Pose.Status = vrapi.OVRHandTrackingStatus_Untracked
+	}
+	if r.unsure {
+		frame.Pose.FingerConfidences[vrapi.OVRHandFinger_Index] = vrapi.OVRConfidence_LOW
+	}
+	return frame
+}
+
+// replay feeds frames to a Recognizer and returns the pinch events as
+// "type@time".
+func replay(config Config, frames []recorded) []string {
+	r := NewRecognizer(config)
+	var events []string
+	for _, frame := range frames {
+		for _, event := range r.Update(frame.frame()) {
+			switch event.Type {
+			case PinchBegin, PinchHold, PinchEnd:
+				events = append(events, fmt.Sprintf("%v@%.2f", event.Type, event.Time))
+			}
+		}
+	}
+	return events
+}
+
+func TestPinchReplay(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config // The default config if zero.
+		frames []recorded
+		events []string
+	}{
+		{
+			name: "begin and end",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95},
+				{time: 0.02, strength: 0.95},
+				{time: 0.04, strength: 0.95},
+				{time: 0.06, strength: 0.3},
+				{time: 0.08, strength: 0.3},
+				{time: 0.10, strength: 0.3},
+			},
+			events: []string{"PinchBegin@0.04", "PinchHold@0.06", "PinchHold@0.08",
+				"PinchEnd@0.10"},
+		},
+		{
+			name: "debounce ignores a blip",
+			frames: []recorded{
+				{time: 0.00, strength: 0},
+				{time: 0.02, strength: 0.95},
+				{time: 0.04, strength: 0},
+				{time: 0.06, strength: 0.95},
+				{time: 0.08, strength: 0},
+			},
+		},
+		{
+			name: "debounce ignores a dropout",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95},
+				{time: 0.04, strength: 0.95},
+				{time: 0.06, strength: 0.3},
+				{time: 0.08, strength: 0.95},
+			},
+			events: []string{"PinchBegin@0.04", "PinchHold@0.06", "PinchHold@0.08"},
+		},
+		{
+			name: "hysteresis holds between the thresholds",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95},
+				{time: 0.04, strength: 0.95},
+				{time: 0.08, strength: 0.7},
+				{time: 0.12, strength: 0.61},
+				{time: 0.16, strength: 0.89},
+			},
+			events: []string{"PinchBegin@0.04", "PinchHold@0.08", "PinchHold@0.12",
+				"PinchHold@0.16"},
+		},
+		{
+			name: "hysteresis does not begin between the thresholds",
+			frames: []recorded{
+				{time: 0.00, strength: 0.7},
+				{time: 0.04, strength: 0.89},
+				{time: 0.08, strength: 0.8},
+			},
+		},
+		{
+			name: "tracking loss ends right away",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95},
+				{time: 0.04, strength: 0.95},
+				{time: 0.06, strength: 0.95, untracked: true},
+				{time: 0.08, strength: 0.95, untracked: true},
+				// Found again, the pinch is debounced from scratch.
+				{time: 0.10, strength: 0.95},
+				{time: 0.12, strength: 0.95},
+				{time: 0.14, strength: 0.95},
+			},
+			events: []string{"PinchBegin@0.04", "PinchEnd@0.06", "PinchBegin@0.14"},
+		},
+		{
+			name: "invalid pointer keeps pinching",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95},
+				{time: 0.04, strength: 0.95},
+				{time: 0.06, strength: 0.95, noPointer: true},
+				{time: 0.08, strength: 0.3, noPointer: true},
+				{time: 0.12, strength: 0.3, noPointer: true},
+			},
+			events: []string{"PinchBegin@0.04", "PinchHold@0.06", "PinchHold@0.08",
+				"PinchEnd@0.12"},
+		},
+		{
+			name:   "no debounce",
+			config: Config{NoDebounce: true},
+			frames: []recorded{
+				{time: 0.00, strength: 0},
+				{time: 0.02, strength: 0.95},
+				{time: 0.04, strength: 0},
+				{time: 0.06, strength: 0.95},
+			},
+			events: []string{"PinchBegin@0.02", "PinchEnd@0.04", "PinchBegin@0.06"},
+		},
+		{
+			name: "low confidence keeps the state",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95},
+				{time: 0.04, strength: 0.95},
+				{time: 0.06, strength: 0, unsure: true},
+				{time: 0.10, strength: 0, unsure: true},
+				{time: 0.12, strength: 0},
+				{time: 0.16, strength: 0},
+			},
+			events: []string{"PinchBegin@0.04", "PinchHold@0.06", "PinchHold@0.10",
+				"PinchHold@0.12", "PinchEnd@0.16"},
+		},
+		{
+			name: "low confidence does not begin",
+			frames: []recorded{
+				{time: 0.00, strength: 0.95, unsure: true},
+				{time: 0.04, strength: 0.95, unsure: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if events := replay(test.config, test.frames); !reflect.DeepEqual(events, test.events) {
+				t.Errorf("events %q, want %q", events, test.events)
+			}
+		})
+	}
+}
+
+// curled bends every joint of finger so Curl returns 2.1 radians.
+func curled(pose *vrapi.HandPose, finger vrapi.OVRHandFinger) {
+	for _, bone := range fingerBones[finger] {
+		pose.BoneRotations[bone] = mgl.Quat{
+			W: float32(math.Cos(0.35)),
+			V: mgl.Vec3{float32(math.Sin(0.35)), 0, 0},
+		}
+	}
+}
+
+func TestGestureReplay(t *testing.T) {
+	openPalm := func(time float64) *Frame {
+		return recorded{time: time}.frame()
+	}
+	point := func(time float64) *Frame {
+		frame := recorded{time: time}.frame()
+		curled(frame.Pose, vrapi.OVRHandFinger_Middle)
+		curled(frame.Pose, vrapi.OVRHandFinger_Ring)
+		curled(frame.Pose, vrapi.OVRHandFinger_Pinky)
+		return frame
+	}
+	unsure := func(time float64) *Frame {
+		frame := point(time)
+		frame.Pose.HandConfidence = vrapi.OVRConfidence_LOW
+		return frame
+	}
+
+	frames := []*Frame{
+		openPalm(0.00),
+		openPalm(0.02),
+		openPalm(0.04),
+		point(0.06),
+		openPalm(0.08), // Too short to end the open palm.
+		point(0.10),
+		point(0.12),
+		point(0.14),
+		unsure(0.16),
+		unsure(0.20),
+	}
+
+	r := NewRecognizer(Config{})
+	var events []string
+	for _, frame := range frames {
+		for _, event := range r.Update(frame) {
+			events = append(events, fmt.Sprintf("%v %v@%.2f", event.Type, event.Gesture,
+				event.Time))
+		}
+	}
+
+	want := []string{
+		"GestureBegin OpenPalm@0.04",
+		"GestureEnd OpenPalm@0.14",
+		"GestureBegin Point@0.14",
+		"GestureEnd Point@0.20",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events %q, want %q", events, want)
+	}
+	if g := r.Gesture(); g != GestureNone {
+		t.Errorf("Gesture() is %v after losing confidence, want None", g)
+	}
+}
+
+func TestGestureNoDebounce(t *testing.T) {
+	openPalm := recorded{time: 0}.frame()
+	point := recorded{time: 0.02}.frame()
+	curled(point.Pose, vrapi.OVRHandFinger_Middle)
+	curled(point.Pose, vrapi.OVRHandFinger_Ring)
+	curled(point.Pose, vrapi.OVRHandFinger_Pinky)
+
+	r := NewRecognizer(Config{NoDebounce: true})
+	var events []string
+	for _, frame := range []*Frame{openPalm, point} {
+		for _, event := range r.Update(frame) {
+			events = append(events, fmt.Sprintf("%v %v@%.2f", event.Type, event.Gesture,
+				event.Time))
+		}
+	}
+
+	want := []string{
+		"GestureBegin OpenPalm@0.00",
+		"GestureEnd OpenPalm@0.02",
+		"GestureBegin Point@0.02",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events %q, want %q", events, want)
+	}
+}
+
+func TestCurl(t *testing.T) {
+	pose := recorded{}.frame().Pose
+	if curl := Curl(pose, vrapi.OVRHandFinger_Index); curl > 1e-6 {
+		t.Errorf("straight finger curled %v", curl)
+	}
+	curled(pose, vrapi.OVRHandFinger_Index)
+	if curl := Curl(pose, vrapi.OVRHandFinger_Index); math.Abs(float64(curl)-2.1) > 1e-4 {
+		t.Errorf("curled finger curled %v, want 2.1", curl)
+	}
+}
+
+func TestAim(t *testing.T) {
+	pointer := &vrapi.OVRInputStateStandardPointer{
+		PointerPose:      vrapi.OVRPosef{Orientation: mgl.QuatIdent(), Position: mgl.Vec3{1, 2, 3}},
+		InputStateStatus: vrapi.OVRInputStateStandardPointerStatus_PointerValid,
+	}
+
+	r := NewRecognizer(Config{})
+	frame := recorded{}.frame()
+	if _, ok := r.Aim(); ok {
+		t.Error("Aim valid before any frame")
+	}
+
+	frame.Pointer = pointer
+	r.Update(frame)
+	if pose, ok := r.Aim(); !ok || pose != pointer.PointerPose {
+		t.Errorf("Aim() = %v, %v, want %v, true", pose, ok, pointer.PointerPose)
+	}
+
+	frame.Pose.Status = vrapi.OVRHandTrackingStatus_Untracked
+	r.Update(frame)
+	if _, ok := r.Aim(); ok {
+		t.Error("Aim valid while the hand is untracked")
+	}
+
+	frame.Pose.Status = vrapi.OVRHandTrackingStatus_Tracked
+	pointer.InputStateStatus = 0
+	r.Update(frame)
+	if _, ok := r.Aim(); ok {
+		t.Error("Aim valid without PointerValid")
+	}
+}
@@ -0,0 +1,229 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <stdlib.h>
+#include <VrApi.h>
+#include <VrApi_Input.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Hand tracking. Hands are enumerated by EnumerateInputDevices as
+// OVRControllerType_Hand. The pose, skeleton and mesh are copied out of the
+// versioned C structs into the Go types below, trimmed to the counts the
+// runtime reported and with every orientation converted to Hamilton. See
+// GetCurrentInputState about vrApp.
+
+type OVRInputHandCapabilities struct {
+	Header OVRInputCapabilityHeader
+
+	HandCapabilities  OVRHandCapabilities
+	StateCapabilities OVRHandStateCapabilities
+}
+
+// Read with GetCurrentInputState with Header.ControllerType set to
+// OVRControllerType_Hand.
+type OVRInputStateHand struct {
+	Header OVRInputStateHeader
+
+	// How far the fingers are into each pinch, 0 to 1. Indexed by
+	// OVRHandPinchStrength.
+	PinchStrength [OVRHand_MaxPinchStrengths]float32
+
+	// Pointing ray for UI interactions, only valid when InputStateStatus has
+	// OVRInputStateHandStatus_PointerValid.
+	PointerPose OVRPosef
+
+	InputStateStatus OVRInputStateHandStatus
+}
+
+// GetHandState is GetCurrentInputState for a hand.
+func GetHandState(vrApp *OVRMobile, deviceID OVRDeviceID) (OVRInputStateHand, error) {
+	var state OVRInputStateHand
+	state.Header.ControllerType = OVRControllerType_Hand
+	err := GetCurrentInputState(vrApp, deviceID, &state.Header)
+
+	return state, err
+}
+
+type HandPose struct {
+	// Not a bit field, either tracked or not.
+	Status OVRHandTrackingStatus
+
+	// Root pose of the hand in world space. Not to be confused with the
+	// root bone's transform which can still be offset from this by the
+	// skeleton's rest pose.
+	RootPose OVRPosef
+
+	// Current rotation of each bone. Indexed by OVRHandBone.
+	BoneRotations [OVRHand_MaxBones]mgl.Quat
+
+	// Time stamp requested and time stamp of the sample the pose was
+	// extrapolated from, both in global system time.
+	RequestedTimeStamp float64
+	SampleTimeStamp    float64
+
+	// Confidence that the whole hand pose is correct.
+	HandConfidence OVRConfidence
+	// Scale of the hand relative to the original hand model, 1.0 by
+	// default. Can change at any time.
+	HandScale float32
+	// Confidence that each finger pose is correct. Indexed by OVRHandFinger.
+	FingerConfidences [OVRHand_MaxFingers]OVRConfidence
+}
+
+type BoneCapsule struct {
+	// Bone the capsule is on.
+	BoneIndex OVRHandBone
+	// The ends of the cylinder inscribed in the capsule and the centers of
+	// its half sphere caps.
+	Points [2]mgl.Vec3
+	Radius float32
+}
+
+type HandSkeleton struct {
+	// Transform of each bone in local (parent) space.
+	BonePoses []OVRPosef
+	// Parent of each bone, OVRHandBone_Invalid for the root.
+	BoneParentIndices []OVRHandBone
+	// Not necessarily one per bone.
+	Capsules []BoneCapsule
+}
+
+type HandMesh struct {
+	VertexPositions []mgl.Vec3
+	// Triangles as indices into the vertex arrays.
+	Indices       []int16
+	VertexNormals []mgl.Vec3
+	VertexUV0     []mgl.Vec2
+	// Bones each vertex is weighted to, an index < 0 means no weight.
+	BlendIndices [][4]int16
+	BlendWeights []mgl.Vec4
+}
+
+// GetHandPose returns the pose of the hand deviceID predicted for absTime.
+func GetHandPose(vrApp *OVRMobile, deviceID OVRDeviceID, absTime float64) (HandPose, error) {
+	var cPose C.ovrHandPose
+	cPose.Header.Version = C.ovrHandVersion_1
+
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	res := C.vrapi_GetHandPose(cOVR, C.uint(deviceID), C.double(absTime), &cPose.Header)
+	if err := resultError("vrapi_GetHandPose", OVRResult(res)); err != nil {
+		return HandPose{}, err
+	}
+
+	pose := HandPose{
+		Status:             OVRHandTrackingStatus(cPose.Status),
+		RootPose:           *(*OVRPosef)(unsafe.Pointer(&cPose.RootPose)),
+		RequestedTimeStamp: float64(cPose.RequestedTimeStamp),
+		SampleTimeStamp:    float64(cPose.SampleTimeStamp),
+		HandConfidence:     OVRConfidence(cPose.HandConfidence),
+		HandScale:          float32(cPose.HandScale),
+	}
+	posefToHamilton(&pose.RootPose)
+	for i := range pose.BoneRotations {
+		rotation := *(*mgl.Quat)(unsafe.Pointer(&cPose.BoneRotations[i]))
+		pose.BoneRotations[i] = jplToHamiltonQuats(rotation)
+	}
+	for i := range pose.FingerConfidences {
+		pose.FingerConfidences[i] = OVRConfidence(cPose.FingerConfidences[i])
+	}
+
+	return pose, nil
+}
+
+// GetHandSkeleton returns the rest pose skeleton of a hand.
+func GetHandSkeleton(vrApp *OVRMobile, handedness OVRHandedness) (HandSkeleton, error) {
+	var cSkeleton C.ovrHandSkeleton
+	cSkeleton.Header.Version = C.ovrHandVersion_1
+
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	res := C.vrapi_GetHandSkeleton(cOVR, C.ovrHandedness(handedness), &cSkeleton.Header)
+	if err := resultError("vrapi_GetHandSkeleton", OVRResult(res)); err != nil {
+		return HandSkeleton{}, err
+	}
+
+	// Compared as uint32, a huge count would wrap negative as an int on 32-bit.
+	if uint32(cSkeleton.NumBones) > uint32(OVRHand_MaxBones) ||
+		uint32(cSkeleton.NumCapsules) > OVRHand_MaxCapsules {
+		return HandSkeleton{}, errors.New("vrapi_GetHandSkeleton returned more bones or capsules than fit")
+	}
+	numBones := int(cSkeleton.NumBones)
+	numCapsules := int(cSkeleton.NumCapsules)
+
+	skeleton := HandSkeleton{
+		BonePoses:         make([]OVRPosef, numBones),
+		BoneParentIndices: make([]OVRHandBone, numBones),
+		Capsules:          make([]BoneCapsule, numCapsules),
+	}
+	for i := 0; i < numBones; i++ {
+		skeleton.BonePoses[i] = *(*OVRPosef)(unsafe.Pointer(&cSkeleton.BonePoses[i]))
+		posefToHamilton(&skeleton.BonePoses[i])
+		skeleton.BoneParentIndices[i] = OVRHandBone(cSkeleton.BoneParentIndices[i])
+	}
+	for i := 0; i < numCapsules; i++ {
+		cCapsule := &cSkeleton.Capsules[i]
+		skeleton.Capsules[i] = BoneCapsule{
+			BoneIndex: OVRHandBone(cCapsule.BoneIndex),
+			Points: [2]mgl.Vec3{
+				*(*mgl.Vec3)(unsafe.Pointer(&cCapsule.Points[0])),
+				*(*mgl.Vec3)(unsafe.Pointer(&cCapsule.Points[1])),
+			},
+			Radius: float32(cCapsule.Radius),
+		}
+	}
+
+	return skeleton, nil
+}
+
+// GetHandMesh returns the skinned mesh of a hand.
+func GetHandMesh(vrApp *OVRMobile, handedness OVRHandedness) (HandMesh, error) {
+	// Too big (~200KB) to put on the stack.
+	cMesh := (*C.ovrHandMesh)(C.calloc(1, C.sizeof_ovrHandMesh))
+	defer C.free(unsafe.Pointer(cMesh))
+	cMesh.Header.Version = C.ovrHandVersion_1
+
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	res := C.vrapi_GetHandMesh(cOVR, C.ovrHandedness(handedness), &cMesh.Header)
+	if err := resultError("vrapi_GetHandMesh", OVRResult(res)); err != nil {
+		return HandMesh{}, err
+	}
+
+	if uint32(cMesh.NumVertices) > OVRHand_MaxVertices ||
+		uint32(cMesh.NumIndices) > OVRHand_MaxIndices {
+		return HandMesh{}, errors.New("vrapi_GetHandMesh returned more vertices or indices than fit")
+	}
+	numVertices := int(cMesh.NumVertices)
+	numIndices := int(cMesh.NumIndices)
+
+	mesh := HandMesh{
+		VertexPositions: make([]mgl.Vec3, numVertices),
+		Indices:         make([]int16, numIndices),
+		VertexNormals:   make([]mgl.Vec3, numVertices),
+		VertexUV0:       make([]mgl.Vec2, numVertices),
+		BlendIndices:    make([][4]int16, numVertices),
+		BlendWeights:    make([]mgl.Vec4, numVertices),
+	}
+	copy(mesh.VertexPositions,
+		(*[OVRHand_MaxVertices]mgl.Vec3)(unsafe.Pointer(&cMesh.VertexPositions))[:numVertices])
+	copy(mesh.Indices,
+		(*[OVRHand_MaxIndices]int16)(unsafe.Pointer(&cMesh.Indices))[:numIndices])
+	copy(mesh.VertexNormals,
+		(*[OVRHand_MaxVertices]mgl.Vec3)(unsafe.Pointer(&cMesh.VertexNormals))[:numVertices])
+	copy(mesh.VertexUV0,
+		(*[OVRHand_MaxVertices]mgl.Vec2)(unsafe.Pointer(&cMesh.VertexUV0))[:numVertices])
+	copy(mesh.BlendIndices,
+		(*[OVRHand_MaxVertices][4]int16)(unsafe.Pointer(&cMesh.BlendIndices))[:numVertices])
+	copy(mesh.BlendWeights,
+		(*[OVRHand_MaxVertices]mgl.Vec4)(unsafe.Pointer(&cMesh.BlendWeights))[:numVertices])
+
+	return mesh, nil
+}
@@ -0,0 +1,158 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestHandPose(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	const handID = 3
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_Hand), handID)
+	// The hand is turned 90 degrees around Y and the index finger's first
+	// bone 90 degrees around X, both in the runtime's x, y, z, w order.
+	s, cos := float32(math.Sin(math.Pi/4)), float32(math.Cos(math.Pi/4))
+	bones := make([][4]float32, OVRHand_MaxBones)
+	for i := range bones {
+		bones[i] = [4]float32{0, 0, 0, 1}
+	}
+	bones[OVRHandBone_Index1] = [4]float32{s, 0, 0, cos}
+	fakevrapi.SetHandPose(handID, fakevrapi.HandPose{
+		Status:            int(OVRHandTrackingStatus_Tracked),
+		RootOrientation:   [4]float32{0, s, 0, cos},
+		RootPosition:      [3]float32{0.2, 1.1, -0.4},
+		BoneRotations:     bones,
+		SampleTimeStamp:   1.25,
+		HandConfidence:    int(OVRConfidence_HIGH),
+		HandScale:         1.1,
+		FingerConfidences: []int{int(OVRConfidence_HIGH), int(OVRConfidence_LOW)},
+	})
+
+	pose, err := GetHandPose(vrApp, handID, 1.5)
+	if err != nil {
+		t.Fatalf("GetHandPose: %v", err)
+	}
+	want := HandPose{
+		Status: OVRHandTrackingStatus_Tracked,
+		RootPose: OVRPosef{
+			Orientation: mgl.Quat{W: cos, V: mgl.Vec3{0, s, 0}},
+			Position:    mgl.Vec3{0.2, 1.1, -0.4},
+		},
+		RequestedTimeStamp: 1.5,
+		SampleTimeStamp:    1.25,
+		HandConfidence:     OVRConfidence_HIGH,
+		HandScale:          1.1,
+	}
+	for i := range want.BoneRotations {
+		want.BoneRotations[i] = mgl.QuatIdent()
+	}
+	want.BoneRotations[OVRHandBone_Index1] = mgl.Quat{W: cos, V: mgl.Vec3{s, 0, 0}}
+	want.FingerConfidences[OVRHandFinger_Thumb] = OVRConfidence_HIGH
+	if pose != want {
+		t.Errorf("GetHandPose() = %+v, want %+v", pose, want)
+	}
+
+	if _, err := GetHandPose(vrApp, 4, 1.5); err == nil {
+		t.Error("GetHandPose for an unknown device succeeded")
+	}
+}
+
+func TestHandSkeleton(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	s, cos := float32(math.Sin(math.Pi/4)), float32(math.Cos(math.Pi/4))
+	fakevrapi.SetHandSkeleton(int(HAND_LEFT), []fakevrapi.Bone{
+		{Orientation: [4]float32{0, 0, 0, 1}, Parent: int(OVRHandBone_Invalid)},
+		{Orientation: [4]float32{0, 0, s, cos}, Position: [3]float32{0, 0, 0.04},
+			Parent: int(OVRHandBone_WristRoot)},
+	}, []fakevrapi.Capsule{
+		{Bone: int(OVRHandBone_ForearmStub), Points: [2][3]float32{{0, 0, 0}, {0, 0, 0.04}},
+			Radius: 0.01},
+	})
+
+	skeleton, err := GetHandSkeleton(vrApp, HAND_LEFT)
+	if err != nil {
+		t.Fatalf("GetHandSkeleton: %v", err)
+	}
+	want := HandSkeleton{
+		BonePoses: []OVRPosef{
+			{Orientation: mgl.QuatIdent()},
+			{Orientation: mgl.Quat{W: cos, V: mgl.Vec3{0, 0, s}}, Position: mgl.Vec3{0, 0, 0.04}},
+		},
+		BoneParentIndices: []OVRHandBone{OVRHandBone_Invalid, OVRHandBone_WristRoot},
+		Capsules: []BoneCapsule{
+			{BoneIndex: OVRHandBone_ForearmStub, Points: [2]mgl.Vec3{{0, 0, 0}, {0, 0, 0.04}},
+				Radius: 0.01},
+		},
+	}
+	if !reflect.DeepEqual(skeleton, want) {
+		t.Errorf("GetHandSkeleton() = %+v, want %+v", skeleton, want)
+	}
+
+	// The right hand was never set up.
+	skeleton, err = GetHandSkeleton(vrApp, HAND_RIGHT)
+	if err != nil || len(skeleton.BonePoses) != 0 || len(skeleton.Capsules) != 0 {
+		t.Errorf("GetHandSkeleton(HAND_RIGHT) = %+v, %v, want no bones", skeleton, err)
+	}
+}
+
+func TestHandMesh(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	fakevrapi.SetHandMesh(int(HAND_RIGHT), fakevrapi.HandMesh{
+		Positions:    [][3]float32{{0, 0, 0}, {0.01, 0, 0}, {0, 0.01, 0}},
+		Normals:      [][3]float32{{0, 0, 1}, {0, 0, 1}, {0, 0, 1}},
+		UV0:          [][2]float32{{0, 0}, {1, 0}, {0, 1}},
+		BlendIndices: [][4]int16{{0, -1, -1, -1}, {0, 1, -1, -1}, {1, -1, -1, -1}},
+		BlendWeights: [][4]float32{{1, 0, 0, 0}, {0.5, 0.5, 0, 0}, {1, 0, 0, 0}},
+		Indices:      []int16{0, 1, 2},
+	})
+
+	mesh, err := GetHandMesh(vrApp, HAND_RIGHT)
+	if err != nil {
+		t.Fatalf("GetHandMesh: %v", err)
+	}
+	want := HandMesh{
+		VertexPositions: []mgl.Vec3{{0, 0, 0}, {0.01, 0, 0}, {0, 0.01, 0}},
+		Indices:         []int16{0, 1, 2},
+		VertexNormals:   []mgl.Vec3{{0, 0, 1}, {0, 0, 1}, {0, 0, 1}},
+		VertexUV0:       []mgl.Vec2{{0, 0}, {1, 0}, {0, 1}},
+		BlendIndices:    [][4]int16{{0, -1, -1, -1}, {0, 1, -1, -1}, {1, -1, -1, -1}},
+		BlendWeights:    []mgl.Vec4{{1, 0, 0, 0}, {0.5, 0.5, 0, 0}, {1, 0, 0, 0}},
+	}
+	if !reflect.DeepEqual(mesh, want) {
+		t.Errorf("GetHandMesh() = %+v, want %+v", mesh, want)
+	}
+}
+
+// Counts past the end of the arrays are rejected, including ones that would
+// wrap negative as a 32-bit int.
+func TestHandCountsTooLarge(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	for _, count := range []uint32{OVRHand_MaxVertices + 1, OVRHand_MaxIndices + 1, 0x80000000,
+		math.MaxUint32} {
+
+		fakevrapi.SetHandCounts(int(HAND_LEFT), count)
+		if skeleton, err := GetHandSkeleton(vrApp, HAND_LEFT); err == nil {
+			t.Errorf("GetHandSkeleton with %d bones returned %d bones", count,
+				len(skeleton.BonePoses))
+		}
+		if mesh, err := GetHandMesh(vrApp, HAND_LEFT); err == nil {
+			t.Errorf("GetHandMesh with %d vertices returned %d vertices", count,
+				len(mesh.VertexPositions))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <stdlib.h>
+#include <VrApi.h>
+#include <VrApi_Input.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Only one of SetHapticVibrationSimple or SetHapticVibrationBuffer may be
+// called per device per frame, further calls fail with
+// OVRError_InvalidOperation. See GetCurrentInputState about vrApp.
+
+// HapticBuffer is the Go side of ovrHapticBuffer.
+type HapticBuffer struct {
+	// Start time of the buffer.
+	BufferTime float64
+	// True if this is the end of the buffers being sent.
+	Terminated bool
+	// Amplitudes from 0 to 255, each played for the device's
+	// HapticSampleDurationMS. At most HapticSamplesMax per buffer.
+	Samples []uint8
+}
+
+// SetHapticVibrationSimple sets the vibration level of a device with
+// OVRControllerCaps_HasSimpleHapticVibration. intensity is from 0 to 1.
+func SetHapticVibrationSimple(vrApp *OVRMobile, deviceID OVRDeviceID, intensity float32) error {
+	if intensity < 0 || intensity > 1 {
+		return &Error{Op: "vrapi_SetHapticVibrationSimple", Code: OVRError_InvalidParameter}
+	}
+
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	res := C.vrapi_SetHapticVibrationSimple(cOVR, C.uint(deviceID), C.float(intensity))
+	return resultError("vrapi_SetHapticVibrationSimple", OVRResult(res))
+}
+
+// SetHapticVibrationBuffer queues buffer on a device with
+// OVRControllerCaps_HasBufferedHapticVibration.
+func SetHapticVibrationBuffer(vrApp *OVRMobile, deviceID OVRDeviceID, buffer *HapticBuffer) error {
+	var cBuffer C.ovrHapticBuffer
+	cBuffer.BufferTime = C.double(buffer.BufferTime)
+	cBuffer.NumSamples = C.uint32_t(len(buffer.Samples))
+	cBuffer.Terminated = C.bool(buffer.Terminated)
+	if len(buffer.Samples) > 0 {
+		// The struct holds a pointer so the samples have to be in C memory.
+		cBuffer.HapticBuffer = (*C.uint8_t)(C.CBytes(buffer.Samples))
+		defer C.free(unsafe.Pointer(cBuffer.HapticBuffer))
+	}
+
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	res := C.vrapi_SetHapticVibrationBuffer(cOVR, C.uint(deviceID), &cBuffer)
+	return resultError("vrapi_SetHapticVibrationBuffer", OVRResult(res))
+}
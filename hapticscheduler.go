@@ -0,0 +1,109 @@
+package vrapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// HapticScheduler plays waveforms longer than a single haptic buffer by
+// handing the device a correctly sized piece of it every frame.
+//
+// Submit does the actual submission, NewControllerHapticScheduler points it
+// at SetHapticVibrationBuffer. Anything else (e.g. a func recording the
+// buffers) can be used to drive the scheduler without a device.
+type HapticScheduler struct {
+	Submit func(buffer *HapticBuffer) error
+
+	samplesMax     int
+	sampleDuration time.Duration
+
+	pending   []uint8
+	terminate bool // Send a terminated buffer to cut off playback.
+}
+
+// NewHapticScheduler takes the HapticSamplesMax and HapticSampleDurationMS
+// the device reported in its capabilities.
+func NewHapticScheduler(samplesMax, sampleDurationMS uint32,
+	submit func(buffer *HapticBuffer) error) (*HapticScheduler, error) {
+
+	if samplesMax == 0 || sampleDurationMS == 0 {
+		return nil, fmt.Errorf("haptic samples max %d and sample duration %dms must be positive",
+			samplesMax, sampleDurationMS)
+	}
+
+	return &HapticScheduler{
+		Submit:         submit,
+		samplesMax:     int(samplesMax),
+		sampleDuration: time.Duration(sampleDurationMS) * time.Millisecond,
+	}, nil
+}
+
+// NewControllerHapticScheduler schedules buffers for a tracked remote with
+// OVRControllerCaps_HasBufferedHapticVibration.
+func NewControllerHapticScheduler(vrApp *OVRMobile,
+	caps *OVRInputTrackedRemoteCapabilities) (*HapticScheduler, error) {
+
+	if caps.ControllerCapabilities&OVRControllerCaps_HasBufferedHapticVibration == 0 {
+		return nil, fmt.Errorf("device %d has no buffered haptic vibration", caps.Header.DeviceID)
+	}
+
+	deviceID := caps.Header.DeviceID
+	return NewHapticScheduler(caps.HapticSamplesMax, caps.HapticSampleDurationMS,
+		func(buffer *HapticBuffer) error {
+			return SetHapticVibrationBuffer(vrApp, deviceID, buffer)
+		})
+}
+
+// Play queues samples after anything still playing.
+func (s *HapticScheduler) Play(samples []uint8) {
+	s.pending = append(s.pending, samples...)
+	s.terminate = false
+}
+
+// Stop drops the queued samples and cuts off the device on the next Update.
+func (s *HapticScheduler) Stop() {
+	s.pending = nil
+	s.terminate = true
+}
+
+// Playing reports whether samples are still waiting to be submitted.
+func (s *HapticScheduler) Playing() bool {
+	return len(s.pending) > 0
+}
+
+// Update is called once per frame, with the frame's predicted display time
+// and the time until the next frame, and submits at most one buffer holding
+// enough samples to cover frameInterval. If Submit fails nothing is consumed
+// and the same samples are submitted by the next Update.
+func (s *HapticScheduler) Update(displayTime float64, frameInterval time.Duration) error {
+	if len(s.pending) == 0 && !s.terminate {
+		return nil
+	}
+
+	n := int((frameInterval + s.sampleDuration - 1) / s.sampleDuration)
+	if n < 1 {
+		n = 1
+	}
+	if n > s.samplesMax {
+		n = s.samplesMax
+	}
+	if n > len(s.pending) {
+		n = len(s.pending)
+	}
+
+	buffer := HapticBuffer{
+		BufferTime: displayTime,
+		Samples:    s.pending[:n:n],
+		Terminated: n == len(s.pending),
+	}
+	if err := s.Submit(&buffer); err != nil {
+		return err
+	}
+
+	s.pending = s.pending[n:]
+	if len(s.pending) == 0 {
+		s.pending = nil
+	}
+	s.terminate = false
+	return nil
+}
@@ -0,0 +1,134 @@
+package vrapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// hapticDevice records every buffer submitted to it and fails while fail is
+// set.
+type hapticDevice struct {
+	fail    bool
+	buffers []HapticBuffer
+}
+
+var errHapticDevice = errors.New("haptic device busy")
+
+func (d *hapticDevice) submit(buffer *HapticBuffer) error {
+	if d.fail {
+		return errHapticDevice
+	}
+	recorded := *buffer
+	recorded.Samples = append([]uint8(nil), buffer.Samples...)
+	d.buffers = append(d.buffers, recorded)
+	return nil
+}
+
+func hapticSamples(from, to uint8) []uint8 {
+	var samples []uint8
+	for i := from; i < to; i++ {
+		samples = append(samples, i)
+	}
+	return samples
+}
+
+func TestHapticScheduler(t *testing.T) {
+	device := &hapticDevice{}
+	s, err := NewHapticScheduler(10, 2, device.submit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Play(hapticSamples(0, 25))
+
+	steps := []struct {
+		displayTime float64
+		interval    time.Duration
+		fail        bool
+	}{
+		{1, 11 * time.Millisecond, false},  // 6 samples cover 11ms.
+		{2, 11 * time.Millisecond, true},   // Nothing consumed.
+		{3, 11 * time.Millisecond, false},  // The same 6 samples again.
+		{4, 100 * time.Millisecond, false}, // Capped at samplesMax.
+		{5, 100 * time.Millisecond, false}, // The last 3, terminated.
+		{6, 100 * time.Millisecond, false}, // Nothing left to play.
+	}
+	for i, step := range steps {
+		device.fail = step.fail
+		err := s.Update(step.displayTime, step.interval)
+		if step.fail != (err != nil) {
+			t.Errorf("step %d: Update returned %v", i, err)
+		}
+	}
+
+	want := []HapticBuffer{
+		{BufferTime: 1, Samples: hapticSamples(0, 6)},
+		{BufferTime: 3, Samples: hapticSamples(6, 12)},
+		{BufferTime: 4, Samples: hapticSamples(12, 22)},
+		{BufferTime: 5, Samples: hapticSamples(22, 25), Terminated: true},
+	}
+	if !reflect.DeepEqual(device.buffers, want) {
+		t.Errorf("submitted %+v\nwant %+v", device.buffers, want)
+	}
+	if s.Playing() {
+		t.Error("still playing after every sample was submitted")
+	}
+}
+
+func TestHapticSchedulerStop(t *testing.T) {
+	device := &hapticDevice{}
+	s, err := NewHapticScheduler(10, 2, device.submit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Play(hapticSamples(0, 25))
+	if err := s.Update(1, 4*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	s.Stop()
+	if s.Playing() {
+		t.Error("still playing after Stop")
+	}
+
+	// The terminated buffer is retried until the device takes it.
+	device.fail = true
+	if err := s.Update(2, 4*time.Millisecond); !errors.Is(err, errHapticDevice) {
+		t.Errorf("Update returned %v, want the device error", err)
+	}
+	device.fail = false
+	for _, displayTime := range []float64{3, 4} {
+		if err := s.Update(displayTime, 4*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []HapticBuffer{
+		{BufferTime: 1, Samples: hapticSamples(0, 2)},
+		{BufferTime: 3, Terminated: true},
+	}
+	if !reflect.DeepEqual(device.buffers, want) {
+		t.Errorf("submitted %+v\nwant %+v", device.buffers, want)
+	}
+
+	// Play after Stop starts over.
+	s.Play(hapticSamples(0, 1))
+	if err := s.Update(5, 4*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	last := device.buffers[len(device.buffers)-1]
+	if !reflect.DeepEqual(last, HapticBuffer{BufferTime: 5, Samples: []uint8{0}, Terminated: true}) {
+		t.Errorf("submitted %+v after playing again", last)
+	}
+}
+
+func TestNewHapticSchedulerInvalid(t *testing.T) {
+	if _, err := NewHapticScheduler(0, 2, nil); err == nil {
+		t.Error("NewHapticScheduler with no samples returned nil error")
+	}
+	if _, err := NewHapticScheduler(10, 0, nil); err == nil {
+		t.Error("NewHapticScheduler with no sample duration returned nil error")
+	}
+}
@@ -0,0 +1,171 @@
+package vrapi
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// Details about the Oculus Remote input device. Read with
+// GetInputDeviceCapabilities with Header.Type set to
+// OVRControllerType_TrackedRemote.
+type OVRInputTrackedRemoteCapabilities struct {
+	Header OVRInputCapabilityHeader
+
+	ControllerCapabilities OVRControllerCapabilities
+	ButtonCapabilities     OVRButton // Buttons the controller has.
+
+	// Maximum coordinates of the Trackpad, bottom right exclusive.
+	// For a 300x200 Trackpad, return 299x199
+	TrackpadMaxX uint16
+	TrackpadMaxY uint16
+
+	// Size of the Trackpad in mm (millimeters)
+	TrackpadSizeX float32
+	TrackpadSizeY float32
+
+	// added in API version 1.1.13.0
+	HapticSamplesMax       uint32 // Maximum submittable samples for the haptics buffer
+	HapticSampleDurationMS uint32 // length in milliseconds of a sample in the haptics buffer.
+
+	// added in API version 1.1.15.0
+	TouchCapabilities OVRTouch // Touches the controller can sense.
+	Reserved4         uint32
+	Reserved5         uint32
+}
+
+// GetTrackedRemoteCapabilities is GetInputDeviceCapabilities for a device
+// EnumerateInputDevices reported as OVRControllerType_TrackedRemote.
+func GetTrackedRemoteCapabilities(vrApp *OVRMobile,
+	deviceID OVRDeviceID) (OVRInputTrackedRemoteCapabilities, error) {
+
+	var caps OVRInputTrackedRemoteCapabilities
+	caps.Header.Type = OVRControllerType_TrackedRemote
+	caps.Header.DeviceID = deviceID
+	err := GetInputDeviceCapabilities(vrApp, &caps.Header)
+
+	return caps, err
+}
+
+// GetTrackedRemoteState is GetCurrentInputState for a tracked remote.
+func GetTrackedRemoteState(vrApp *OVRMobile,
+	deviceID OVRDeviceID) (OVRInputStateTrackedRemote, error) {
+
+	var state OVRInputStateTrackedRemote
+	state.Header.ControllerType = OVRControllerType_TrackedRemote
+	err := GetCurrentInputState(vrApp, deviceID, &state.Header)
+
+	return state, err
+}
+
+// inputStateHooks post-process an input state once the runtime filled it in,
+// e.g. converting poses to Hamilton. Only controller types with a Go mirror
+// are listed, the runtime writes the full struct for the type so passing any
+// other type could overrun the Go value.
+var inputStateHooks = map[OVRControllerType]func(header *OVRInputStateHeader){
+	OVRControllerType_TrackedRemote: func(header *OVRInputStateHeader) {}, // No poses.
+	OVRControllerType_StandardPointer: func(header *OVRInputStateHeader) {
+		pointer := (*OVRInputStateStandardPointer)(unsafe.Pointer(header))
+		posefToHamilton(&pointer.GripPose)
+		posefToHamilton(&pointer.PointerPose)
+	},
+	OVRControllerType_Hand: func(header *OVRInputStateHeader) {
+		hand := (*OVRInputStateHand)(unsafe.Pointer(header))
+		posefToHamilton(&hand.PointerPose)
+	},
+}
+
+// posefToHamilton converts a pose the runtime returned in place, every
+// input pose goes through it.
+func posefToHamilton(pose *OVRPosef) {
+	pose.Orientation = jplToHamiltonQuats(pose.Orientation)
+}
+
+// Controller types GetInputDeviceCapabilities has a Go mirror for.
+var inputCapabilityTypes = map[OVRControllerType]bool{
+	OVRControllerType_TrackedRemote:   true,
+	OVRControllerType_StandardPointer: true,
+	OVRControllerType_Hand:            true,
+}
+
+var buttonNames = []struct {
+	button OVRButton
+	name   string
+}{
+	{OVRButton_A, "A"},
+	{OVRButton_B, "B"},
+	{OVRButton_RThumb, "RThumb"},
+	{OVRButton_RShoulder, "RShoulder"},
+	{OVRButton_X, "X"},
+	{OVRButton_Y, "Y"},
+	{OVRButton_LThumb, "LThumb"},
+	{OVRButton_LShoulder, "LShoulder"},
+	{OVRButton_Up, "Up"},
+	{OVRButton_Down, "Down"},
+	{OVRButton_Left, "Left"},
+	{OVRButton_Right, "Right"},
+	{OVRButton_Enter, "Enter"},
+	{OVRButton_Back, "Back"},
+	{OVRButton_GripTrigger, "GripTrigger"},
+	{OVRButton_Trigger, "Trigger"},
+	{OVRButton_Joystick, "Joystick"},
+}
+
+// String lists the set buttons e.g. "A|Trigger".
+func (b OVRButton) String() string {
+	if b == 0 {
+		return "0"
+	}
+
+	var names []string
+	for _, n := range buttonNames {
+		if b&n.button != 0 {
+			names = append(names, n.name)
+			b &^= n.button
+		}
+	}
+	if b != 0 {
+		names = append(names, fmt.Sprintf("0x%X", uint32(b)))
+	}
+	return strings.Join(names, "|")
+}
+
+// OVRTouch_BaseState is left out since it is ThumbUp|IndexPointing.
+var touchNames = []struct {
+	touch OVRTouch
+	name  string
+}{
+	{OVRTouch_A, "A"},
+	{OVRTouch_B, "B"},
+	{OVRTouch_X, "X"},
+	{OVRTouch_Y, "Y"},
+	{OVRTouch_TrackPad, "TrackPad"},
+	{OVRTouch_Joystick, "Joystick"},
+	{OVRTouch_IndexTrigger, "IndexTrigger"},
+	{OVRTouch_ThumbUp, "ThumbUp"},
+	{OVRTouch_IndexPointing, "IndexPointing"},
+	{OVRTouch_LThumb, "LThumb"},
+	{OVRTouch_RThumb, "RThumb"},
+	{OVRTouch_ThumbRest, "ThumbRest"},
+	{OVRTouch_LThumbRest, "LThumbRest"},
+	{OVRTouch_RThumbRest, "RThumbRest"},
+}
+
+// String lists the set touches e.g. "A|IndexTrigger".
+func (t OVRTouch) String() string {
+	if t == 0 {
+		return "0"
+	}
+
+	var names []string
+	for _, n := range touchNames {
+		if t&n.touch != 0 {
+			names = append(names, n.name)
+			t &^= n.touch
+		}
+	}
+	if t != 0 {
+		names = append(names, fmt.Sprintf("0x%X", uint32(t)))
+	}
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,241 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestButtonString(t *testing.T) {
+	tests := []struct {
+		buttons OVRButton
+		want    string
+	}{
+		{0, "0"},
+		{OVRButton_A, "A"},
+		{OVRButton_Joystick, "Joystick"},
+		{OVRButton_A | OVRButton_Trigger, "A|Trigger"},
+		{OVRButton_Joystick | OVRButton_X | OVRButton_Back, "X|Back|Joystick"},
+		{OVRButton_B | 0x10, "B|0x10"},
+		{0x10 | 0x40000000, "0x40000010"},
+	}
+	for _, test := range tests {
+		if got := test.buttons.String(); got != test.want {
+			t.Errorf("OVRButton(0x%X).String() = %q, want %q", uint32(test.buttons), got,
+				test.want)
+		}
+	}
+}
+
+func TestTouchString(t *testing.T) {
+	tests := []struct {
+		touches OVRTouch
+		want    string
+	}{
+		{0, "0"},
+		{OVRTouch_IndexTrigger, "IndexTrigger"},
+		{OVRTouch_BaseState, "ThumbUp|IndexPointing"},
+		{OVRTouch_RThumbRest | OVRTouch_A | OVRTouch_Joystick, "A|Joystick|RThumbRest"},
+		{OVRTouch_X | 0x80, "X|0x80"},
+		{0x10000, "0x10000"},
+	}
+	for _, test := range tests {
+		if got := test.touches.String(); got != test.want {
+			t.Errorf("OVRTouch(0x%X).String() = %q, want %q", uint32(test.touches), got,
+				test.want)
+		}
+	}
+}
+
+// runtimeQuat lays out the quaternion x, y, z, w the way the runtime writes
+// it, which is how it sits in an mgl.Quat before the conversion to Hamilton.
+func runtimeQuat(x, y, z, w float32) mgl.Quat {
+	return mgl.Quat{W: x, V: mgl.Vec3{y, z, w}}
+}
+
+func structBytes(p unsafe.Pointer, size uintptr) []byte {
+	return unsafe.Slice((*byte)(p), size)
+}
+
+func TestInputStateDispatch(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	const remoteID, pointerID, handID = 1, 2, 3
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_TrackedRemote), remoteID)
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_StandardPointer), pointerID)
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_Hand), handID)
+
+	remote := OVRInputStateTrackedRemote{
+		Buttons:  OVRButton_A,
+		Joystick: mgl.Vec2{0.5, -0.25},
+	}
+	fakevrapi.SetInputState(remoteID, structBytes(unsafe.Pointer(&remote), unsafe.Sizeof(remote)))
+	pointer := OVRInputStateStandardPointer{
+		PointerPose:      OVRPosef{Orientation: runtimeQuat(0, 1, 0, 0), Position: mgl.Vec3{1, 2, 3}},
+		GripPose:         OVRPosef{Orientation: runtimeQuat(0, 0, 1, 0)},
+		PointerStrength:  0.5,
+		InputStateStatus: OVRInputStateStandardPointerStatus_PointerValid,
+	}
+	fakevrapi.SetInputState(pointerID, structBytes(unsafe.Pointer(&pointer), unsafe.Sizeof(pointer)))
+	hand := OVRInputStateHand{
+		PointerPose:      OVRPosef{Orientation: runtimeQuat(1, 0, 0, 0)},
+		InputStateStatus: OVRInputStateHandStatus_PointerValid,
+	}
+	fakevrapi.SetInputState(handID, structBytes(unsafe.Pointer(&hand), unsafe.Sizeof(hand)))
+
+	gotRemote, err := GetTrackedRemoteState(vrApp, remoteID)
+	if err != nil {
+		t.Fatalf("GetTrackedRemoteState: %v", err)
+	}
+	if gotRemote.Buttons != remote.Buttons || gotRemote.Joystick != remote.Joystick {
+		t.Errorf("tracked remote state is %+v, want %+v", gotRemote, remote)
+	}
+
+	var gotPointer OVRInputStateStandardPointer
+	gotPointer.Header.ControllerType = OVRControllerType_StandardPointer
+	if err := GetCurrentInputState(vrApp, pointerID, &gotPointer.Header); err != nil {
+		t.Fatalf("GetCurrentInputState for the pointer: %v", err)
+	}
+	// Both poses are converted.
+	wantPointer := pointer
+	wantPointer.Header.ControllerType = OVRControllerType_StandardPointer
+	wantPointer.PointerPose.Orientation = mgl.Quat{W: 0, V: mgl.Vec3{0, 1, 0}}
+	wantPointer.GripPose.Orientation = mgl.Quat{W: 0, V: mgl.Vec3{0, 0, 1}}
+	if gotPointer != wantPointer {
+		t.Errorf("pointer state is %+v, want %+v", gotPointer, wantPointer)
+	}
+
+	gotHand, err := GetHandState(vrApp, handID)
+	if err != nil {
+		t.Fatalf("GetHandState: %v", err)
+	}
+	if want := (mgl.Quat{W: 0, V: mgl.Vec3{1, 0, 0}}); gotHand.PointerPose.Orientation != want {
+		t.Errorf("hand pointer orientation is %v, want %v", gotHand.PointerPose.Orientation, want)
+	}
+
+	// Types without a Go mirror never reach the runtime, it would write past
+	// the end of the Go value.
+	calls := fakevrapi.GetState().InputCalls
+	for _, controllerType := range []OVRControllerType{OVRControllerType_None,
+		OVRControllerType_Gamepad, OVRControllerType_Reserved0} {
+
+		var vrapiErr *Error
+		header := OVRInputStateHeader{ControllerType: controllerType}
+		err := GetCurrentInputState(vrApp, remoteID, &header)
+		if !errors.As(err, &vrapiErr) || vrapiErr.Code != OVRError_InvalidParameter {
+			t.Errorf("GetCurrentInputState for type %d returned %v", controllerType, err)
+		}
+		capsHeader := OVRInputCapabilityHeader{Type: controllerType, DeviceID: remoteID}
+		err = GetInputDeviceCapabilities(vrApp, &capsHeader)
+		if !errors.As(err, &vrapiErr) || vrapiErr.Code != OVRError_InvalidParameter {
+			t.Errorf("GetInputDeviceCapabilities for type %d returned %v", controllerType, err)
+		}
+	}
+	if n := fakevrapi.GetState().InputCalls; n != calls {
+		t.Errorf("%d calls for unmirrored types reached the runtime", n-calls)
+	}
+}
+
+func TestInputCapabilitiesDispatch(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	const remoteID, pointerID, handID = 1, 2, 3
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_TrackedRemote), remoteID)
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_StandardPointer), pointerID)
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_Hand), handID)
+
+	remote := OVRInputTrackedRemoteCapabilities{
+		ControllerCapabilities: OVRControllerCaps_RightHand,
+		ButtonCapabilities:     OVRButton_A | OVRButton_B,
+		HapticSamplesMax:       32,
+	}
+	fakevrapi.SetInputCapabilities(remoteID, structBytes(unsafe.Pointer(&remote), unsafe.Sizeof(remote)))
+	pointer := OVRInputStandardPointerCapabilities{
+		ControllerCapabilities: OVRControllerCaps_LeftHand,
+		HapticSampleDurationMS: 2,
+	}
+	fakevrapi.SetInputCapabilities(pointerID, structBytes(unsafe.Pointer(&pointer), unsafe.Sizeof(pointer)))
+	hand := OVRInputHandCapabilities{HandCapabilities: OVRHandCaps_LeftHand}
+	fakevrapi.SetInputCapabilities(handID, structBytes(unsafe.Pointer(&hand), unsafe.Sizeof(hand)))
+
+	gotRemote, err := GetTrackedRemoteCapabilities(vrApp, remoteID)
+	remote.Header = OVRInputCapabilityHeader{Type: OVRControllerType_TrackedRemote, DeviceID: remoteID}
+	if err != nil || gotRemote != remote {
+		t.Errorf("GetTrackedRemoteCapabilities() = %+v, %v, want %+v", gotRemote, err, remote)
+	}
+
+	var gotPointer OVRInputStandardPointerCapabilities
+	gotPointer.Header = OVRInputCapabilityHeader{Type: OVRControllerType_StandardPointer,
+		DeviceID: pointerID}
+	err = GetInputDeviceCapabilities(vrApp, &gotPointer.Header)
+	pointer.Header = gotPointer.Header
+	if err != nil || gotPointer != pointer {
+		t.Errorf("standard pointer capabilities are %+v, %v, want %+v", gotPointer, err, pointer)
+	}
+
+	var gotHand OVRInputHandCapabilities
+	gotHand.Header = OVRInputCapabilityHeader{Type: OVRControllerType_Hand, DeviceID: handID}
+	err = GetInputDeviceCapabilities(vrApp, &gotHand.Header)
+	hand.Header = gotHand.Header
+	if err != nil || gotHand != hand {
+		t.Errorf("hand capabilities are %+v, %v, want %+v", gotHand, err, hand)
+	}
+}
+
+func TestInputTrackingState(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	const remoteID = 1
+	fakevrapi.AddInputDevice(uint32(OVRControllerType_TrackedRemote), remoteID)
+	// The controller is turned 90 degrees around X, which the runtime writes
+	// as x, y, z, w.
+	s, cos := float32(math.Sin(math.Pi/4)), float32(math.Cos(math.Pi/4))
+	const status = 0x3 // Orientation and position tracked.
+	fakevrapi.SetInputTracking(remoteID, status, [4]float32{s, 0, 0, cos}, [3]float32{0.2, 1, -0.3})
+
+	tracking, err := GetInputTrackingState(vrApp, remoteID, 1.5)
+	if err != nil {
+		t.Fatalf("GetInputTrackingState: %v", err)
+	}
+	want := OVRPosef{
+		Orientation: mgl.Quat{W: cos, V: mgl.Vec3{s, 0, 0}},
+		Position:    mgl.Vec3{0.2, 1, -0.3},
+	}
+	if tracking.Status != status || tracking.HeadPose.Pose != want {
+		t.Errorf("GetInputTrackingState() has status %#x and pose %v, want %#x and %v",
+			tracking.Status, tracking.HeadPose.Pose, status, want)
+	}
+	if absTime := fakevrapi.GetState().InputTrackingTime; absTime != 1.5 {
+		t.Errorf("runtime was asked for the pose at %v, want 1.5", absTime)
+	}
+
+	if _, err := GetInputTrackingState(vrApp, 2, 1.5); !errors.Is(err, OVRError_DeviceUnavailable) {
+		t.Errorf("GetInputTrackingState for an unknown device returned %v", err)
+	}
+}
+
+// Every type with capabilities has an input state and the other way around.
+func TestInputTypesMatch(t *testing.T) {
+	for controllerType := range inputCapabilityTypes {
+		if _, ok := inputStateHooks[controllerType]; !ok {
+			t.Errorf("controller type %d has capabilities but no input state hook",
+				controllerType)
+		}
+	}
+	for controllerType := range inputStateHooks {
+		if !inputCapabilityTypes[controllerType] {
+			t.Errorf("controller type %d has an input state hook but no capabilities",
+				controllerType)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package vrapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// InputRuntime is the part of the runtime InputDevices polls. MobileInput is
+// the real one, a fake can script devices appearing and disappearing.
+type InputRuntime interface {
+	EnumerateInputDevices(index uint32, capsHeader *OVRInputCapabilityHeader) error
+	GetInputDeviceCapabilities(capsHeader *OVRInputCapabilityHeader) error
+}
+
+// MobileInput is the InputRuntime of an OVRMobile in vr mode.
+type MobileInput struct {
+	VrApp *OVRMobile
+}
+
+func (m MobileInput) EnumerateInputDevices(index uint32,
+	capsHeader *OVRInputCapabilityHeader) error {
+
+	return EnumerateInputDevices(m.VrApp, index, capsHeader)
+}
+
+func (m MobileInput) GetInputDeviceCapabilities(capsHeader *OVRInputCapabilityHeader) error {
+	return GetInputDeviceCapabilities(m.VrApp, capsHeader)
+}
+
+// InputDevice is a connected device and its capabilities. Only the
+// capabilities field matching Type is set, none for types without a Go
+// mirror.
+type InputDevice struct {
+	ID         OVRDeviceID
+	Type       OVRControllerType
+	Handedness OVRHandedness // From the LeftHand / RightHand capability bits.
+
+	TrackedRemote   *OVRInputTrackedRemoteCapabilities
+	StandardPointer *OVRInputStandardPointerCapabilities
+	Hand            *OVRInputHandCapabilities
+}
+
+type InputDeviceEventType int
+
+const (
+	InputDeviceConnected InputDeviceEventType = iota
+	InputDeviceDisconnected
+)
+
+func (t InputDeviceEventType) String() string {
+	switch t {
+	case InputDeviceConnected:
+		return "Connected"
+	case InputDeviceDisconnected:
+		return "Disconnected"
+	}
+	return fmt.Sprintf("InputDeviceEventType(%d)", int(t))
+}
+
+type InputDeviceEvent struct {
+	Type   InputDeviceEventType
+	Device InputDevice
+}
+
+// InputDevices keeps track of the connected input devices. Call Update once
+// a frame.
+type InputDevices struct {
+	runtime InputRuntime
+	devices map[OVRDeviceID]InputDevice
+}
+
+func NewInputDevices(runtime InputRuntime) *InputDevices {
+	return &InputDevices{
+		runtime: runtime,
+		devices: make(map[OVRDeviceID]InputDevice),
+	}
+}
+
+// Update enumerates every device and returns what connected and
+// disconnected since the last call. Disconnects are reported first. A device
+// whose capabilities can not be read yet is left out and retried next Update.
+// A device that comes back with a different type is reported as
+// disconnected and connected again.
+func (d *InputDevices) Update() []InputDeviceEvent {
+	seen := make(map[OVRDeviceID]OVRControllerType, len(d.devices))
+	var connected []InputDevice
+	for index := uint32(0); ; index++ {
+		var header OVRInputCapabilityHeader
+		if err := d.runtime.EnumerateInputDevices(index, &header); err != nil {
+			break
+		}
+
+		seen[header.DeviceID] = header.Type
+		if device, ok := d.devices[header.DeviceID]; ok && device.Type == header.Type {
+			continue
+		}
+
+		device, err := d.capabilities(header)
+		if err != nil {
+			delete(seen, header.DeviceID)
+			continue
+		}
+		connected = append(connected, device)
+	}
+
+	var events []InputDeviceEvent
+	for _, id := range d.sortedIDs() {
+		device := d.devices[id]
+		if deviceType, ok := seen[id]; !ok || deviceType != device.Type {
+			delete(d.devices, id)
+			events = append(events, InputDeviceEvent{Type: InputDeviceDisconnected, Device: device})
+		}
+	}
+	for _, device := range connected {
+		d.devices[device.ID] = device
+		events = append(events, InputDeviceEvent{Type: InputDeviceConnected, Device: device})
+	}
+
+	return events
+}
+
+func (d *InputDevices) capabilities(header OVRInputCapabilityHeader) (InputDevice, error) {
+	device := InputDevice{ID: header.DeviceID, Type: header.Type}
+
+	var err error
+	switch header.Type {
+	case OVRControllerType_TrackedRemote:
+		caps := &OVRInputTrackedRemoteCapabilities{Header: header}
+		err = d.runtime.GetInputDeviceCapabilities(&caps.Header)
+		device.TrackedRemote = caps
+		device.Handedness = controllerHandedness(caps.ControllerCapabilities)
+	case OVRControllerType_StandardPointer:
+		caps := &OVRInputStandardPointerCapabilities{Header: header}
+		err = d.runtime.GetInputDeviceCapabilities(&caps.Header)
+		device.StandardPointer = caps
+		device.Handedness = controllerHandedness(caps.ControllerCapabilities)
+	case OVRControllerType_Hand:
+		caps := &OVRInputHandCapabilities{Header: header}
+		err = d.runtime.GetInputDeviceCapabilities(&caps.Header)
+		device.Hand = caps
+		switch {
+		case caps.HandCapabilities&OVRHandCaps_LeftHand != 0:
+			device.Handedness = HAND_LEFT
+		case caps.HandCapabilities&OVRHandCaps_RightHand != 0:
+			device.Handedness = HAND_RIGHT
+		}
+	}
+
+	return device, err
+}
+
+func controllerHandedness(caps OVRControllerCapabilities) OVRHandedness {
+	switch {
+	case caps&OVRControllerCaps_LeftHand != 0:
+		return HAND_LEFT
+	case caps&OVRControllerCaps_RightHand != 0:
+		return HAND_RIGHT
+	}
+	return HAND_UNKNOWN
+}
+
+func (d *InputDevices) sortedIDs() []OVRDeviceID {
+	ids := make([]OVRDeviceID, 0, len(d.devices))
+	for id := range d.devices {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Devices returns the connected devices ordered by ID.
+func (d *InputDevices) Devices() []InputDevice {
+	devices := make([]InputDevice, 0, len(d.devices))
+	for _, id := range d.sortedIDs() {
+		devices = append(devices, d.devices[id])
+	}
+	return devices
+}
+
+func (d *InputDevices) Device(id OVRDeviceID) (InputDevice, bool) {
+	device, ok := d.devices[id]
+	return device, ok
+}
+
+// Find returns the first connected device, by ID, of controllerType held in
+// handedness. HAND_UNKNOWN matches either hand.
+func (d *InputDevices) Find(controllerType OVRControllerType,
+	handedness OVRHandedness) (InputDevice, bool) {
+
+	for _, id := range d.sortedIDs() {
+		device := d.devices[id]
+		if device.Type != controllerType {
+			continue
+		}
+		if handedness == HAND_UNKNOWN || device.Handedness == handedness {
+			return device, true
+		}
+	}
+	return InputDevice{}, false
+}
@@ -0,0 +1,205 @@
+package vrapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+type scriptedDevice struct {
+	id         OVRDeviceID
+	deviceType OVRControllerType
+	handedness OVRHandedness
+}
+
+// scriptedInput is an InputRuntime whose devices are set by the test.
+// Capability queries for the IDs in failCaps fail that many more times.
+type scriptedInput struct {
+	devices   []scriptedDevice
+	failCaps  map[OVRDeviceID]int
+	capsCalls int
+}
+
+var errNoDevice = errors.New("no device")
+
+func (s *scriptedInput) EnumerateInputDevices(index uint32,
+	capsHeader *OVRInputCapabilityHeader) error {
+
+	if index >= uint32(len(s.devices)) {
+		return errNoDevice
+	}
+	device := s.devices[index]
+	*capsHeader = OVRInputCapabilityHeader{Type: device.deviceType, DeviceID: device.id}
+	return nil
+}
+
+func (s *scriptedInput) GetInputDeviceCapabilities(capsHeader *OVRInputCapabilityHeader) error {
+	s.capsCalls++
+	if s.failCaps[capsHeader.DeviceID] > 0 {
+		s.failCaps[capsHeader.DeviceID]--
+		return errNoDevice
+	}
+
+	var device scriptedDevice
+	for _, d := range s.devices {
+		if d.id == capsHeader.DeviceID {
+			device = d
+		}
+	}
+	if device.deviceType != capsHeader.Type {
+		return errNoDevice
+	}
+
+	controllerCaps := map[OVRHandedness]OVRControllerCapabilities{
+		HAND_LEFT:  OVRControllerCaps_LeftHand,
+		HAND_RIGHT: OVRControllerCaps_RightHand,
+	}[device.handedness]
+	handCaps := map[OVRHandedness]OVRHandCapabilities{
+		HAND_LEFT:  OVRHandCaps_LeftHand,
+		HAND_RIGHT: OVRHandCaps_RightHand,
+	}[device.handedness]
+
+	// The header is the first field of every capabilities struct.
+	switch capsHeader.Type {
+	case OVRControllerType_TrackedRemote:
+		(*OVRInputTrackedRemoteCapabilities)(unsafe.Pointer(capsHeader)).ControllerCapabilities = controllerCaps
+	case OVRControllerType_StandardPointer:
+		(*OVRInputStandardPointerCapabilities)(unsafe.Pointer(capsHeader)).ControllerCapabilities = controllerCaps
+	case OVRControllerType_Hand:
+		(*OVRInputHandCapabilities)(unsafe.Pointer(capsHeader)).HandCapabilities = handCaps
+	}
+	return nil
+}
+
+func TestInputDevices(t *testing.T) {
+	remote := func(id OVRDeviceID, handedness OVRHandedness) scriptedDevice {
+		return scriptedDevice{id, OVRControllerType_TrackedRemote, handedness}
+	}
+	hand := func(id OVRDeviceID, handedness OVRHandedness) scriptedDevice {
+		return scriptedDevice{id, OVRControllerType_Hand, handedness}
+	}
+	pointer := func(id OVRDeviceID, handedness OVRHandedness) scriptedDevice {
+		return scriptedDevice{id, OVRControllerType_StandardPointer, handedness}
+	}
+
+	runtime := &scriptedInput{failCaps: map[OVRDeviceID]int{4: 1}}
+	d := NewInputDevices(runtime)
+
+	steps := []struct {
+		name      string
+		devices   []scriptedDevice
+		events    []string
+		connected []OVRDeviceID
+		capsCalls int
+	}{
+		{
+			name:      "connect",
+			devices:   []scriptedDevice{remote(1, HAND_LEFT), hand(2, HAND_RIGHT)},
+			events:    []string{"Connected 1", "Connected 2"},
+			connected: []OVRDeviceID{1, 2},
+			capsCalls: 2,
+		},
+		{
+			name:      "nothing changed",
+			devices:   []scriptedDevice{hand(2, HAND_RIGHT), remote(1, HAND_LEFT)},
+			connected: []OVRDeviceID{1, 2},
+			capsCalls: 2,
+		},
+		{
+			name:      "swap a controller",
+			devices:   []scriptedDevice{remote(3, HAND_RIGHT), hand(2, HAND_RIGHT)},
+			events:    []string{"Disconnected 1", "Connected 3"},
+			connected: []OVRDeviceID{2, 3},
+			capsCalls: 3,
+		},
+		{
+			name:      "type changed",
+			devices:   []scriptedDevice{remote(3, HAND_RIGHT), pointer(2, HAND_RIGHT)},
+			events:    []string{"Disconnected 2", "Connected 2"},
+			connected: []OVRDeviceID{2, 3},
+			capsCalls: 4,
+		},
+		{
+			name: "capabilities fail",
+			devices: []scriptedDevice{remote(3, HAND_RIGHT), pointer(2, HAND_RIGHT),
+				hand(4, HAND_LEFT)},
+			connected: []OVRDeviceID{2, 3},
+			capsCalls: 5,
+		},
+		{
+			name: "capabilities retried",
+			devices: []scriptedDevice{remote(3, HAND_RIGHT), pointer(2, HAND_RIGHT),
+				hand(4, HAND_LEFT)},
+			events:    []string{"Connected 4"},
+			connected: []OVRDeviceID{2, 3, 4},
+			capsCalls: 6,
+		},
+		{
+			name:      "disconnect all",
+			events:    []string{"Disconnected 2", "Disconnected 3", "Disconnected 4"},
+			capsCalls: 6,
+		},
+	}
+
+	for _, step := range steps {
+		runtime.devices = step.devices
+		var events []string
+		for _, event := range d.Update() {
+			events = append(events, fmt.Sprintf("%v %d", event.Type, event.Device.ID))
+		}
+		if !reflect.DeepEqual(events, step.events) {
+			t.Errorf("%s: events %q, want %q", step.name, events, step.events)
+		}
+
+		var connected []OVRDeviceID
+		for _, device := range d.Devices() {
+			connected = append(connected, device.ID)
+		}
+		if !reflect.DeepEqual(connected, step.connected) {
+			t.Errorf("%s: connected %v, want %v", step.name, connected, step.connected)
+		}
+		if runtime.capsCalls != step.capsCalls {
+			t.Errorf("%s: %d capability queries, want %d", step.name, runtime.capsCalls,
+				step.capsCalls)
+		}
+	}
+}
+
+func TestInputDevicesFind(t *testing.T) {
+	runtime := &scriptedInput{devices: []scriptedDevice{
+		{1, OVRControllerType_Hand, HAND_LEFT},
+		{2, OVRControllerType_TrackedRemote, HAND_RIGHT},
+		{3, OVRControllerType_Hand, HAND_RIGHT},
+		{4, OVRControllerType_StandardPointer, HAND_LEFT},
+	}}
+	d := NewInputDevices(runtime)
+	d.Update()
+
+	tests := []struct {
+		controllerType OVRControllerType
+		handedness     OVRHandedness
+		id             OVRDeviceID
+		ok             bool
+	}{
+		{OVRControllerType_Hand, HAND_LEFT, 1, true},
+		{OVRControllerType_Hand, HAND_RIGHT, 3, true},
+		{OVRControllerType_Hand, HAND_UNKNOWN, 1, true},
+		{OVRControllerType_TrackedRemote, HAND_RIGHT, 2, true},
+		{OVRControllerType_TrackedRemote, HAND_LEFT, 0, false},
+		{OVRControllerType_StandardPointer, HAND_LEFT, 4, true},
+	}
+	for _, test := range tests {
+		device, ok := d.Find(test.controllerType, test.handedness)
+		if ok != test.ok || device.ID != test.id {
+			t.Errorf("Find(%d, %d) = %d, %v, want %d, %v", test.controllerType,
+				test.handedness, device.ID, ok, test.id, test.ok)
+		}
+	}
+
+	device, _ := d.Device(3)
+	if device.Hand == nil || device.TrackedRemote != nil || device.StandardPointer != nil {
+		t.Errorf("hand device has capabilities %+v", device)
+	}
+}
@@ -0,0 +1,10 @@
+// Package fakevrapi is a stand in for libvrapi so the vrapi package can be
+// tested on a host without a headset. Importing it links C definitions of
+// every vrapi_* function the vrapi package calls. The fake keeps just enough
+// state (sessions, swap chains, the last submitted frame) for tests to check
+// what the runtime was handed, and counts calls the real runtime would crash
+// or misbehave on.
+//
+// Only test binaries should import it, and never on android where the real
+// libvrapi is linked.
+package fakevrapi
@@ -0,0 +1,738 @@
+//go:build !android
+
+#include <stdarg.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "fakevrapi.h"
+
+fakeState fake_state;
+fakeSubmit fake_lastSubmit;
+fakeScript fake_script;
+
+// Never freed before fake_reset so misuse of a departed session or destroyed
+// swap chain is reported instead of crashing the test.
+struct ovrMobile {
+	int inVrMode;
+	struct ovrMobile* next;
+};
+
+struct ovrTextureSwapChain {
+	int id;
+	int length;
+	int destroyed;
+	struct ovrTextureSwapChain* next;
+};
+
+static struct ovrMobile* mobiles;
+static struct ovrTextureSwapChain* swapChains;
+static int swapChainCount;
+
+static void misuse(const char* format, ...) {
+	va_list args;
+	va_start(args, format);
+	vsnprintf(fake_state.LastMisuse, sizeof(fake_state.LastMisuse), format, args);
+	va_end(args);
+	fake_state.Misuses++;
+}
+
+static void freeLastSubmit(void) {
+	for (int i = 0; i < ovrMaxLayerCount; i++) {
+		free(fake_lastSubmit.LayerCopies[i]);
+	}
+	memset(&fake_lastSubmit, 0, sizeof(fake_lastSubmit));
+}
+
+void fake_reset(void) {
+	while (mobiles != NULL) {
+		struct ovrMobile* next = mobiles->next;
+		free(mobiles);
+		mobiles = next;
+	}
+	while (swapChains != NULL) {
+		struct ovrTextureSwapChain* next = swapChains->next;
+		free(swapChains);
+		swapChains = next;
+	}
+	swapChainCount = 0;
+	freeLastSubmit();
+	memset(&fake_state, 0, sizeof(fake_state));
+	memset(&fake_script, 0, sizeof(fake_script));
+}
+
+unsigned int fake_swapChainHandle(uintptr_t chain, int index) {
+	return ((struct ovrTextureSwapChain*)chain)->id * 100 + index;
+}
+
+static size_t layerSize(ovrLayerType2 type) {
+	switch (type) {
+	case VRAPI_LAYER_TYPE_PROJECTION2:
+		return sizeof(ovrLayerProjection2);
+	case VRAPI_LAYER_TYPE_CYLINDER2:
+		return sizeof(ovrLayerCylinder2);
+	case VRAPI_LAYER_TYPE_CUBE2:
+		return sizeof(ovrLayerCube2);
+	case VRAPI_LAYER_TYPE_EQUIRECT2:
+		return sizeof(ovrLayerEquirect2);
+	case VRAPI_LAYER_TYPE_EQUIRECT3:
+		return sizeof(ovrLayerEquirect3);
+	case VRAPI_LAYER_TYPE_LOADING_ICON2:
+		return sizeof(ovrLayerLoadingIcon2);
+	case VRAPI_LAYER_TYPE_FISHEYE2:
+		return sizeof(ovrLayerFishEye2);
+	default:
+		return 0;
+	}
+}
+
+static int checkMobile(const char* op, ovrMobile* ovr) {
+	if (ovr == NULL || !ovr->inVrMode) {
+		misuse("%s called with an ovrMobile that is not in vr mode", op);
+		return 0;
+	}
+	return 1;
+}
+
+static int checkSwapChain(const char* op, ovrTextureSwapChain* chain) {
+	if (chain == NULL || (uintptr_t)chain <= VRAPI_DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON) {
+		misuse("%s called with swap chain %p", op, (void*)chain);
+		return 0;
+	}
+	if (chain->destroyed) {
+		misuse("%s called with destroyed swap chain %d", op, chain->id);
+		return 0;
+	}
+	return 1;
+}
+
+// Initialization
+
+ovrInitializeStatus vrapi_Initialize(const ovrInitParms* initParms) {
+	(void)initParms;
+	if (fake_state.Initialized) {
+		return VRAPI_INITIALIZE_ALREADY_INITIALIZED;
+	}
+	fake_state.Initialized = 1;
+	return VRAPI_INITIALIZE_SUCCESS;
+}
+
+void vrapi_Shutdown() {
+	if (!fake_state.Initialized) {
+		misuse("vrapi_Shutdown called without vrapi_Initialize");
+	}
+	if (fake_state.LiveSessions != 0) {
+		misuse("vrapi_Shutdown called with %d sessions in vr mode", fake_state.LiveSessions);
+	}
+	fake_state.Initialized = 0;
+}
+
+// Properties
+
+int vrapi_GetSystemPropertyInt(const ovrJava* java, const ovrSystemProperty propType) {
+	(void)java;
+	switch (propType) {
+	case VRAPI_SYS_PROP_NUM_SUPPORTED_SWAPCHAIN_FORMATS:
+		return 2;
+	case VRAPI_SYS_PROP_FOVEATION_AVAILABLE:
+		return fake_script.FoveationAvailable;
+	default:
+		return 0;
+	}
+}
+
+int vrapi_GetSystemPropertyInt64Array(
+	const ovrJava* java,
+	const ovrSystemProperty propType,
+	int64_t* values,
+	int numArrayValues) {
+	(void)java;
+	if (propType != VRAPI_SYS_PROP_SUPPORTED_SWAPCHAIN_FORMATS) {
+		return 0;
+	}
+
+	const int64_t formats[] = {0x8058 /* GL_RGBA8 */, 0x8C43 /* GL_SRGB8_ALPHA8 */};
+	int n = 0;
+	for (; n < numArrayValues && n < 2; n++) {
+		values[n] = formats[n];
+	}
+	return n;
+}
+
+void vrapi_SetPropertyInt(const ovrJava* java, const ovrProperty propType, const int intVal) {
+	(void)java;
+	switch (propType) {
+	case VRAPI_FOVEATION_LEVEL:
+		fake_state.FoveationLevel = intVal;
+		break;
+	case VRAPI_DYNAMIC_FOVEATION_ENABLED:
+		fake_state.DynamicFoveation = intVal;
+		break;
+	default:
+		break;
+	}
+}
+
+bool vrapi_GetPropertyInt(const ovrJava* java, const ovrProperty propType, int* intVal) {
+	(void)java;
+	if (propType == VRAPI_FOVEATION_LEVEL) {
+		*intVal = fake_state.FoveationLevel;
+		return true;
+	}
+	*intVal = 0;
+	return false;
+}
+
+// Vr mode
+
+ovrMobile* vrapi_EnterVrMode(const ovrModeParms* parms) {
+	if (!fake_state.Initialized) {
+		misuse("vrapi_EnterVrMode called without vrapi_Initialize");
+		return NULL;
+	}
+	fake_state.SynchronizationQueue = 0;
+	if (parms->Type == VRAPI_STRUCTURE_TYPE_MODE_PARMS_VULKAN) {
+		if (!fake_state.VulkanSystem) {
+			misuse("vrapi_EnterVrMode with vulkan mode parms called without vrapi_CreateSystemVulkan");
+			return NULL;
+		}
+		fake_state.SynchronizationQueue = ((const ovrModeParmsVulkan*)parms)->SynchronizationQueue;
+	}
+
+	ovrMobile* ovr = calloc(1, sizeof(ovrMobile));
+	ovr->inVrMode = 1;
+	ovr->next = mobiles;
+	mobiles = ovr;
+	fake_state.LiveSessions++;
+	return ovr;
+}
+
+void vrapi_LeaveVrMode(ovrMobile* ovr) {
+	if (!checkMobile("vrapi_LeaveVrMode", ovr)) {
+		return;
+	}
+	ovr->inVrMode = 0;
+	fake_state.LiveSessions--;
+}
+
+double vrapi_GetPredictedDisplayTime(ovrMobile* ovr, long long frameIndex) {
+	checkMobile("vrapi_GetPredictedDisplayTime", ovr);
+	return (double)frameIndex / FAKE_DISPLAY_RATE;
+}
+
+ovrTracking2 vrapi_GetPredictedTracking2(ovrMobile* ovr, double absTimeInSeconds) {
+	checkMobile("vrapi_GetPredictedTracking2", ovr);
+
+	ovrTracking2 tracking;
+	memset(&tracking, 0, sizeof(tracking));
+	tracking.HeadPose.Pose.Orientation.w = 1.0f;
+	tracking.HeadPose.TimeInSeconds = absTimeInSeconds;
+	for (int eye = 0; eye < VRAPI_EYE_COUNT; eye++) {
+		for (int i = 0; i < 4; i++) {
+			tracking.Eye[eye].ProjectionMatrix.M[i][i] = 1.0f;
+			tracking.Eye[eye].ViewMatrix.M[i][i] = 1.0f;
+		}
+		// Tells the eyes apart.
+		tracking.Eye[eye].ViewMatrix.M[0][3] = eye == 0 ? -0.032f : 0.032f;
+	}
+	return tracking;
+}
+
+ovrResult vrapi_SubmitFrame2(ovrMobile* ovr, const ovrSubmitFrameDescription2* frameDescription) {
+	if (!checkMobile("vrapi_SubmitFrame2", ovr)) {
+		return ovrError_InvalidOperation;
+	}
+	if (frameDescription->LayerCount > ovrMaxLayerCount) {
+		misuse("vrapi_SubmitFrame2 called with %u layers", frameDescription->LayerCount);
+		return ovrError_InvalidParameter;
+	}
+
+	freeLastSubmit();
+	fake_lastSubmit.Desc = *frameDescription;
+	for (uint32_t i = 0; i < frameDescription->LayerCount; i++) {
+		const ovrLayerHeader2* layer = frameDescription->Layers[i];
+		fake_lastSubmit.Layers[i] = layer;
+		if (layer == NULL) {
+			misuse("vrapi_SubmitFrame2 layer %u is NULL", i);
+			continue;
+		}
+
+		size_t size = layerSize(layer->Type);
+		fake_lastSubmit.LayerSizes[i] = size;
+		fake_lastSubmit.LayerCopies[i] = malloc(size);
+		memcpy(fake_lastSubmit.LayerCopies[i], layer, size);
+	}
+	fake_state.Submits++;
+	return ovrSuccess;
+}
+
+// Swap chains
+
+static ovrTextureSwapChain* newSwapChain(int length) {
+	ovrTextureSwapChain* chain = calloc(1, sizeof(ovrTextureSwapChain));
+	chain->id = ++swapChainCount;
+	chain->length = length;
+	chain->next = swapChains;
+	swapChains = chain;
+	fake_state.LiveSwapChains++;
+	return chain;
+}
+
+ovrTextureSwapChain* vrapi_CreateTextureSwapChain3(
+	ovrTextureType type,
+	int64_t format,
+	int width,
+	int height,
+	int levels,
+	int bufferCount) {
+	(void)type;
+	(void)format;
+	(void)width;
+	(void)height;
+	(void)levels;
+	return newSwapChain(bufferCount);
+}
+
+ovrTextureSwapChain* vrapi_CreateTextureSwapChain4(const ovrSwapChainCreateInfo* createInfo) {
+	return newSwapChain(createInfo->BufferCount);
+}
+
+ovrTextureSwapChain* vrapi_CreateAndroidSurfaceSwapChain(int width, int height) {
+	(void)width;
+	(void)height;
+	return newSwapChain(1);
+}
+
+ovrTextureSwapChain* vrapi_CreateAndroidSurfaceSwapChain2(int width, int height, bool isProtected) {
+	(void)width;
+	(void)height;
+	(void)isProtected;
+	return newSwapChain(1);
+}
+
+void vrapi_DestroyTextureSwapChain(ovrTextureSwapChain* chain) {
+	if (!checkSwapChain("vrapi_DestroyTextureSwapChain", chain)) {
+		return;
+	}
+	chain->destroyed = 1;
+	fake_state.LiveSwapChains--;
+}
+
+int vrapi_GetTextureSwapChainLength(ovrTextureSwapChain* chain) {
+	if (!checkSwapChain("vrapi_GetTextureSwapChainLength", chain)) {
+		return 0;
+	}
+	return chain->length;
+}
+
+unsigned int vrapi_GetTextureSwapChainHandle(ovrTextureSwapChain* chain, int index) {
+	if (!checkSwapChain("vrapi_GetTextureSwapChainHandle", chain)) {
+		return 0;
+	}
+	if (index < 0 || index >= chain->length) {
+		misuse("vrapi_GetTextureSwapChainHandle index %d out of range", index);
+		return 0;
+	}
+	return fake_swapChainHandle((uintptr_t)chain, index);
+}
+
+jobject vrapi_GetTextureSwapChainAndroidSurface(ovrTextureSwapChain* chain) {
+	checkSwapChain("vrapi_GetTextureSwapChainAndroidSurface", chain);
+	return NULL;
+}
+
+// Tracking space
+
+ovrResult vrapi_SetTrackingSpace(ovrMobile* ovr, ovrTrackingSpace whichSpace) {
+	if (!checkMobile("vrapi_SetTrackingSpace", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	fake_state.TrackingSpace = whichSpace;
+	return ovrSuccess;
+}
+
+ovrTrackingSpace vrapi_GetTrackingSpace(ovrMobile* ovr) {
+	checkMobile("vrapi_GetTrackingSpace", ovr);
+	return fake_state.TrackingSpace;
+}
+
+ovrPosef vrapi_LocateTrackingSpace(ovrMobile* ovr, ovrTrackingSpace target) {
+	checkMobile("vrapi_LocateTrackingSpace", ovr);
+	fake_state.LocatedSpace = target;
+	return fake_script.LocatedPose;
+}
+
+// Boundary
+
+ovrResult vrapi_GetBoundaryGeometry(
+	ovrMobile* ovr,
+	const uint32_t pointsCountInput,
+	uint32_t* pointsCountOutput,
+	ovrVector3f* points) {
+	*pointsCountOutput = 0;
+	if (!checkMobile("vrapi_GetBoundaryGeometry", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	if (!fake_script.BoundaryValid) {
+		return ovrSuccess_BoundaryInvalid;
+	}
+
+	if (pointsCountInput == 0 || points == NULL) {
+		*pointsCountOutput = fake_script.BoundaryPointCount;
+		// The user redraws the boundary right after the application asked
+		// how many points there are.
+		if (fake_script.BoundaryChanged) {
+			fake_script.BoundaryChanged = 0;
+			fake_script.BoundaryPointCount = fake_script.ChangedPointCount;
+			memcpy(fake_script.BoundaryPoints, fake_script.ChangedPoints,
+				sizeof(fake_script.BoundaryPoints));
+		}
+		return ovrSuccess;
+	}
+
+	uint32_t n = pointsCountInput;
+	if (n > fake_script.BoundaryPointCount) {
+		n = fake_script.BoundaryPointCount;
+	}
+	memcpy(points, fake_script.BoundaryPoints, n * sizeof(ovrVector3f));
+	*pointsCountOutput = n;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetBoundaryTriggerState(
+	ovrMobile* ovr,
+	const ovrTrackedDeviceTypeId deviceId,
+	ovrBoundaryTriggerResult* result) {
+	if (!checkMobile("vrapi_GetBoundaryTriggerState", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	if (deviceId < 0 || deviceId >= VRAPI_NUM_TRACKED_DEVICES) {
+		misuse("vrapi_GetBoundaryTriggerState called with device %d", deviceId);
+		return ovrError_InvalidParameter;
+	}
+	if (!fake_script.BoundaryValid) {
+		return ovrSuccess_BoundaryInvalid;
+	}
+	if (!fake_script.TriggerTracked[deviceId]) {
+		return ovrError_NoDevice;
+	}
+	*result = fake_script.TriggerResults[deviceId];
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetBoundaryOrientedBoundingBox(ovrMobile* ovr, ovrPosef* pose, ovrVector3f* scale) {
+	(void)pose;
+	(void)scale;
+	checkMobile("vrapi_GetBoundaryOrientedBoundingBox", ovr);
+	return ovrError_NotImplemented;
+}
+
+ovrResult vrapi_TestPointIsInBoundary(
+	ovrMobile* ovr,
+	const ovrVector3f point,
+	bool* pointInsideBoundary,
+	ovrBoundaryTriggerResult* result) {
+	(void)point;
+	(void)pointInsideBoundary;
+	(void)result;
+	checkMobile("vrapi_TestPointIsInBoundary", ovr);
+	return ovrError_NotImplemented;
+}
+
+ovrResult vrapi_RequestBoundaryVisible(ovrMobile* ovr, const bool visible) {
+	(void)visible;
+	checkMobile("vrapi_RequestBoundaryVisible", ovr);
+	return ovrError_NotImplemented;
+}
+
+ovrResult vrapi_GetBoundaryVisible(ovrMobile* ovr, bool* visible) {
+	(void)visible;
+	checkMobile("vrapi_GetBoundaryVisible", ovr);
+	return ovrError_NotImplemented;
+}
+
+// Input
+
+static fakeInputDevice* findInputDevice(ovrDeviceID deviceID) {
+	for (int i = 0; i < fake_script.InputDeviceCount; i++) {
+		if (fake_script.InputDevices[i].DeviceID == deviceID) {
+			return &fake_script.InputDevices[i];
+		}
+	}
+	return NULL;
+}
+
+static size_t capabilitiesSize(ovrControllerType type) {
+	switch (type) {
+	case ovrControllerType_TrackedRemote:
+		return sizeof(ovrInputTrackedRemoteCapabilities);
+	case ovrControllerType_StandardPointer:
+		return sizeof(ovrInputStandardPointerCapabilities);
+	case ovrControllerType_Hand:
+		return sizeof(ovrInputHandCapabilities);
+	default:
+		return 0;
+	}
+}
+
+static size_t inputStateSize(ovrControllerType type) {
+	switch (type) {
+	case ovrControllerType_TrackedRemote:
+		return sizeof(ovrInputStateTrackedRemote);
+	case ovrControllerType_StandardPointer:
+		return sizeof(ovrInputStateStandardPointer);
+	case ovrControllerType_Hand:
+		return sizeof(ovrInputStateHand);
+	default:
+		return 0;
+	}
+}
+
+ovrResult vrapi_EnumerateInputDevices(
+	ovrMobile* ovr,
+	const uint32_t index,
+	ovrInputCapabilityHeader* capsHeader) {
+	if (!checkMobile("vrapi_EnumerateInputDevices", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	if (index >= (uint32_t)fake_script.InputDeviceCount) {
+		return ovrError_NoDevice;
+	}
+	capsHeader->Type = fake_script.InputDevices[index].Type;
+	capsHeader->DeviceID = fake_script.InputDevices[index].DeviceID;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetInputDeviceCapabilities(ovrMobile* ovr, ovrInputCapabilityHeader* capsHeader) {
+	if (!checkMobile("vrapi_GetInputDeviceCapabilities", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	fake_state.InputCalls++;
+	size_t size = capabilitiesSize(capsHeader->Type);
+	if (size == 0) {
+		misuse("vrapi_GetInputDeviceCapabilities called with controller type %d",
+			capsHeader->Type);
+		return ovrError_InvalidParameter;
+	}
+	fakeInputDevice* device = findInputDevice(capsHeader->DeviceID);
+	if (device == NULL) {
+		return ovrError_DeviceUnavailable;
+	}
+	if (device->Type != capsHeader->Type) {
+		return ovrError_InvalidParameter;
+	}
+
+	// Everything after the header, the struct is as big as its type says.
+	ovrInputCapabilityHeader header = *capsHeader;
+	memcpy(capsHeader, device->Capabilities, size);
+	*capsHeader = header;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetCurrentInputState(
+	ovrMobile* ovr,
+	const ovrDeviceID deviceID,
+	ovrInputStateHeader* inputState) {
+	if (!checkMobile("vrapi_GetCurrentInputState", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	fake_state.InputCalls++;
+	size_t size = inputStateSize(inputState->ControllerType);
+	if (size == 0) {
+		misuse("vrapi_GetCurrentInputState called with controller type %d",
+			inputState->ControllerType);
+		return ovrError_InvalidParameter;
+	}
+	fakeInputDevice* device = findInputDevice(deviceID);
+	if (device == NULL) {
+		return ovrError_DeviceUnavailable;
+	}
+	if (device->Type != inputState->ControllerType) {
+		return ovrError_InvalidParameter;
+	}
+
+	ovrControllerType type = inputState->ControllerType;
+	memcpy(inputState, device->State, size);
+	inputState->ControllerType = type;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetInputTrackingState(
+	ovrMobile* ovr,
+	const ovrDeviceID deviceID,
+	const double absTimeInSeconds,
+	ovrTracking* tracking) {
+	if (!checkMobile("vrapi_GetInputTrackingState", ovr)) {
+		return ovrError_InvalidParameter;
+	}
+	fakeInputDevice* device = findInputDevice(deviceID);
+	if (device == NULL) {
+		return ovrError_DeviceUnavailable;
+	}
+	fake_state.InputTrackingTime = absTimeInSeconds;
+	*tracking = device->Tracking;
+	return ovrSuccess;
+}
+
+// Hands
+
+static int checkHandVersion(const char* op, ovrHandVersion version) {
+	if (version != ovrHandVersion_1) {
+		misuse("%s called with header version %#x", op, (unsigned)version);
+		return 0;
+	}
+	return 1;
+}
+
+ovrResult vrapi_GetHandPose(
+	ovrMobile* ovr,
+	const ovrDeviceID deviceID,
+	const double absTimeInSeconds,
+	ovrHandPoseHeader* header) {
+	if (!checkMobile("vrapi_GetHandPose", ovr) ||
+		!checkHandVersion("vrapi_GetHandPose", header->Version)) {
+		return ovrError_InvalidParameter;
+	}
+	fakeInputDevice* device = findInputDevice(deviceID);
+	if (device == NULL) {
+		return ovrError_DeviceUnavailable;
+	}
+	if (device->Type != ovrControllerType_Hand) {
+		return ovrError_InvalidParameter;
+	}
+
+	ovrHandPose* pose = (ovrHandPose*)header;
+	ovrHandPoseHeader saved = *header;
+	*pose = device->HandPose;
+	pose->Header = saved;
+	pose->RequestedTimeStamp = absTimeInSeconds;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetHandSkeleton(
+	ovrMobile* ovr,
+	const ovrHandedness handedness,
+	ovrHandSkeletonHeader* header) {
+	if (!checkMobile("vrapi_GetHandSkeleton", ovr) ||
+		!checkHandVersion("vrapi_GetHandSkeleton", header->Version)) {
+		return ovrError_InvalidParameter;
+	}
+	if (handedness != VRAPI_HAND_LEFT && handedness != VRAPI_HAND_RIGHT) {
+		return ovrError_InvalidParameter;
+	}
+
+	ovrHandSkeleton* skeleton = (ovrHandSkeleton*)header;
+	ovrHandSkeletonHeader saved = *header;
+	*skeleton = fake_script.HandSkeletons[handedness - 1];
+	skeleton->Header = saved;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetHandMesh(ovrMobile* ovr, const ovrHandedness handedness, ovrHandMeshHeader* header) {
+	if (!checkMobile("vrapi_GetHandMesh", ovr) ||
+		!checkHandVersion("vrapi_GetHandMesh", header->Version)) {
+		return ovrError_InvalidParameter;
+	}
+	if (handedness != VRAPI_HAND_LEFT && handedness != VRAPI_HAND_RIGHT) {
+		return ovrError_InvalidParameter;
+	}
+
+	ovrHandMesh* mesh = (ovrHandMesh*)header;
+	ovrHandMeshHeader saved = *header;
+	*mesh = fake_script.HandMeshes[handedness - 1];
+	mesh->Header = saved;
+	return ovrSuccess;
+}
+
+// Vulkan
+
+// copyExtensions fills in names if it is large enough and sets size to the
+// size needed, including the terminator.
+static ovrResult copyExtensions(const char* op, const char* extensions, char* names,
+	uint32_t* size) {
+	if (!fake_state.Initialized) {
+		misuse("%s called without vrapi_Initialize", op);
+		return ovrError_InvalidOperation;
+	}
+	uint32_t needed = (uint32_t)strlen(extensions) + 1;
+	if (*size < needed) {
+		*size = needed;
+		return ovrError_InvalidParameter;
+	}
+	memcpy(names, extensions, needed);
+	*size = needed;
+	return ovrSuccess;
+}
+
+ovrResult vrapi_GetInstanceExtensionsVulkan(char* extensionNames, uint32_t* extensionNamesSize) {
+	return copyExtensions("vrapi_GetInstanceExtensionsVulkan", fake_script.InstanceExtensions,
+		extensionNames, extensionNamesSize);
+}
+
+ovrResult vrapi_GetDeviceExtensionsVulkan(char* extensionNames, uint32_t* extensionNamesSize) {
+	return copyExtensions("vrapi_GetDeviceExtensionsVulkan", fake_script.DeviceExtensions,
+		extensionNames, extensionNamesSize);
+}
+
+ovrResult vrapi_CreateSystemVulkan(ovrSystemCreateInfoVulkan* systemInfo) {
+	if (!fake_state.Initialized) {
+		misuse("vrapi_CreateSystemVulkan called without vrapi_Initialize");
+		return ovrError_InvalidOperation;
+	}
+	if (fake_state.VulkanSystem) {
+		misuse("vrapi_CreateSystemVulkan called twice");
+		return ovrError_InvalidOperation;
+	}
+	fake_state.VulkanSystem = 1;
+	fake_state.VulkanInstance = (uintptr_t)systemInfo->Instance;
+	fake_state.VulkanPhysicalDevice = (uintptr_t)systemInfo->PhysicalDevice;
+	fake_state.VulkanDevice = (uintptr_t)systemInfo->Device;
+	return ovrSuccess;
+}
+
+void vrapi_DestroySystemVulkan() {
+	if (!fake_state.VulkanSystem) {
+		misuse("vrapi_DestroySystemVulkan called without vrapi_CreateSystemVulkan");
+		return;
+	}
+	fake_state.VulkanSystem = 0;
+}
+
+// Not faked, the functions below only exist so the vrapi package links.
+
+ovrResult vrapi_SetHapticVibrationSimple(ovrMobile* ovr, const ovrDeviceID deviceID, const float intensity) {
+	(void)deviceID;
+	(void)intensity;
+	checkMobile("vrapi_SetHapticVibrationSimple", ovr);
+	return ovrError_NotImplemented;
+}
+
+ovrResult vrapi_SetHapticVibrationBuffer(
+	ovrMobile* ovr,
+	const ovrDeviceID deviceID,
+	const ovrHapticBuffer* hapticBuffer) {
+	(void)deviceID;
+	(void)hapticBuffer;
+	checkMobile("vrapi_SetHapticVibrationBuffer", ovr);
+	return ovrError_NotImplemented;
+}
+
+VkImage vrapi_GetTextureSwapChainBufferVulkan(ovrTextureSwapChain* chain, int index) {
+	(void)index;
+	checkSwapChain("vrapi_GetTextureSwapChainBufferVulkan", chain);
+	return 0;
+}
+
+ovrResult vrapi_GetTextureSwapChainBufferFoveationVulkan(
+	ovrTextureSwapChain* chain,
+	int index,
+	VkImage* image,
+	uint32_t* imageWidth,
+	uint32_t* imageHeight) {
+	(void)index;
+	(void)image;
+	(void)imageWidth;
+	(void)imageHeight;
+	checkSwapChain("vrapi_GetTextureSwapChainBufferFoveationVulkan", chain);
+	return ovrError_NotImplemented;
+}
@@ -0,0 +1,374 @@
+//go:build !android
+
+package fakevrapi
+
+/*
+#cgo CPPFLAGS: -I${SRCDIR}/../../Include
+
+#include "fakevrapi.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// DisplayRate is the refresh rate predicted display times are based on.
+const DisplayRate = C.FAKE_DISPLAY_RATE
+
+// State is a snapshot of the fake runtime.
+type State struct {
+	Initialized    bool
+	LiveSessions   int
+	LiveSwapChains int
+	Submits        int
+	// Misuses counts calls the real runtime would crash or misbehave on,
+	// LastMisuse describes the latest.
+	Misuses    int
+	LastMisuse string
+	// The foveation properties as last set.
+	FoveationLevel   int
+	DynamicFoveation bool
+	TrackingSpace    int
+	// The target of the last vrapi_LocateTrackingSpace.
+	LocatedSpace int
+	// Calls to vrapi_GetInputDeviceCapabilities and vrapi_GetCurrentInputState.
+	InputCalls int
+	// The time passed to the last vrapi_GetInputTrackingState.
+	InputTrackingTime float64
+	// Between vrapi_CreateSystemVulkan and vrapi_DestroySystemVulkan, with
+	// the handles it was last given.
+	VulkanSystem         bool
+	VulkanInstance       uintptr
+	VulkanPhysicalDevice uintptr
+	VulkanDevice         uintptr
+	// The queue in the Vulkan mode parms of the last vrapi_EnterVrMode, 0
+	// after GLES ones.
+	SynchronizationQueue uint64
+}
+
+// Layer is a layer handed to vrapi_SubmitFrame2.
+type Layer struct {
+	// Ptr is the address the runtime was handed.
+	Ptr  uintptr
+	Type int
+	// Bytes is a copy of the layer taken during vrapi_SubmitFrame2.
+	Bytes []byte
+}
+
+// Submit is what the last vrapi_SubmitFrame2 received.
+type Submit struct {
+	Flags        uint32
+	SwapInterval uint32
+	FrameIndex   uint64
+	DisplayTime  float64
+	Layers       []Layer
+}
+
+// Reset forgets all sessions, swap chains and submissions along with
+// anything the Set functions scripted. Pointers handed out before are
+// dangling afterwards.
+func Reset() {
+	C.fake_reset()
+}
+
+// GetState returns the current state of the fake runtime.
+func GetState() State {
+	return State{
+		Initialized:    C.fake_state.Initialized != 0,
+		LiveSessions:   int(C.fake_state.LiveSessions),
+		LiveSwapChains: int(C.fake_state.LiveSwapChains),
+		Submits:        int(C.fake_state.Submits),
+		Misuses:        int(C.fake_state.Misuses),
+		LastMisuse:     C.GoString(&C.fake_state.LastMisuse[0]),
+
+		FoveationLevel:   int(C.fake_state.FoveationLevel),
+		DynamicFoveation: C.fake_state.DynamicFoveation != 0,
+		TrackingSpace:    int(C.fake_state.TrackingSpace),
+		LocatedSpace:     int(C.fake_state.LocatedSpace),
+		InputCalls:       int(C.fake_state.InputCalls),
+
+		InputTrackingTime: float64(C.fake_state.InputTrackingTime),
+
+		VulkanSystem:         C.fake_state.VulkanSystem != 0,
+		VulkanInstance:       uintptr(C.fake_state.VulkanInstance),
+		VulkanPhysicalDevice: uintptr(C.fake_state.VulkanPhysicalDevice),
+		VulkanDevice:         uintptr(C.fake_state.VulkanDevice),
+		SynchronizationQueue: uint64(C.fake_state.SynchronizationQueue),
+	}
+}
+
+// SetFoveationAvailable sets what SYS_PROP_FOVEATION_AVAILABLE reports.
+// Reset makes it unavailable.
+func SetFoveationAvailable(available bool) {
+	C.fake_script.FoveationAvailable = cBool(available)
+}
+
+// SetLocatedPose sets the pose vrapi_LocateTrackingSpace returns.
+// orientation is in the runtime's x, y, z, w order.
+func SetLocatedPose(orientation [4]float32, position [3]float32) {
+	C.fake_script.LocatedPose = cPose(orientation, position)
+}
+
+// SetBoundary sets up a valid boundary made of points. Reset leaves the
+// boundary invalid.
+func SetBoundary(points [][3]float32) {
+	C.fake_script.BoundaryValid = 1
+	C.fake_script.BoundaryPointCount = copyPoints(&C.fake_script.BoundaryPoints, points)
+}
+
+// ChangeBoundary replaces the boundary with points right after the next
+// vrapi_GetBoundaryGeometry that only asks for the number of points.
+func ChangeBoundary(points [][3]float32) {
+	C.fake_script.BoundaryChanged = 1
+	C.fake_script.ChangedPointCount = copyPoints(&C.fake_script.ChangedPoints, points)
+}
+
+func copyPoints(dst *[C.FAKE_MAX_BOUNDARY_POINTS]C.ovrVector3f, points [][3]float32) C.uint32_t {
+	n := copy((*[C.FAKE_MAX_BOUNDARY_POINTS][3]float32)(unsafe.Pointer(dst))[:], points)
+	return C.uint32_t(n)
+}
+
+// SetVulkanExtensions sets the space delimited extension lists
+// vrapi_GetInstanceExtensionsVulkan and vrapi_GetDeviceExtensionsVulkan
+// report.
+func SetVulkanExtensions(instance, device string) {
+	copyString(&C.fake_script.InstanceExtensions, instance)
+	copyString(&C.fake_script.DeviceExtensions, device)
+}
+
+func copyString(dst *[C.FAKE_MAX_EXTENSIONS_SIZE]C.char, s string) {
+	if len(s) >= len(dst) {
+		panic("fakevrapi: extension list too long")
+	}
+	n := copy((*[len(dst)]byte)(unsafe.Pointer(dst))[:], s)
+	dst[n] = 0
+}
+
+// AddInputDevice adds a device to the end of the ones
+// vrapi_EnumerateInputDevices reports.
+func AddInputDevice(controllerType, deviceID uint32) {
+	if C.fake_script.InputDeviceCount == C.FAKE_MAX_INPUT_DEVICES {
+		panic("fakevrapi: too many input devices")
+	}
+	device := &C.fake_script.InputDevices[C.fake_script.InputDeviceCount]
+	device.Type = C.ovrControllerType(controllerType)
+	device.DeviceID = C.ovrDeviceID(deviceID)
+	C.fake_script.InputDeviceCount++
+}
+
+// SetInputCapabilities sets the capabilities struct of an added device, in
+// the runtime's layout. The header is ignored.
+func SetInputCapabilities(deviceID uint32, caps []byte) {
+	device := inputDevice(deviceID)
+	copy((*[len(device.Capabilities)]byte)(unsafe.Pointer(&device.Capabilities))[:], caps)
+}
+
+// SetInputState sets the input state struct of an added device, in the
+// runtime's layout. The controller type in the header is ignored.
+func SetInputState(deviceID uint32, state []byte) {
+	device := inputDevice(deviceID)
+	copy((*[len(device.State)]byte)(unsafe.Pointer(&device.State))[:], state)
+}
+
+// SetInputTracking sets what vrapi_GetInputTrackingState reports for an
+// added device. orientation is in the runtime's x, y, z, w order.
+func SetInputTracking(deviceID uint32, status uint32, orientation [4]float32,
+	position [3]float32) {
+
+	device := inputDevice(deviceID)
+	device.Tracking = C.ovrTracking{Status: C.uint(status)}
+	device.Tracking.HeadPose.Pose = cPose(orientation, position)
+}
+
+// HandPose is what vrapi_GetHandPose reports for a hand. Orientations are
+// in the runtime's x, y, z, w order.
+type HandPose struct {
+	Status            int
+	RootOrientation   [4]float32
+	RootPosition      [3]float32
+	BoneRotations     [][4]float32
+	SampleTimeStamp   float64
+	HandConfidence    int
+	HandScale         float32
+	FingerConfidences []int
+}
+
+// SetHandPose sets the pose of an added hand.
+func SetHandPose(deviceID uint32, pose HandPose) {
+	handPose := &inputDevice(deviceID).HandPose
+	if len(pose.BoneRotations) > len(handPose.BoneRotations) ||
+		len(pose.FingerConfidences) > len(handPose.FingerConfidences) {
+		panic("fakevrapi: too many bones or fingers")
+	}
+	*handPose = C.ovrHandPose{
+		Status:          C.ovrHandTrackingStatus(pose.Status),
+		RootPose:        cPose(pose.RootOrientation, pose.RootPosition),
+		SampleTimeStamp: C.double(pose.SampleTimeStamp),
+		HandConfidence:  C.ovrConfidence(pose.HandConfidence),
+		HandScale:       C.float(pose.HandScale),
+	}
+	copy((*[len(handPose.BoneRotations)][4]float32)(unsafe.Pointer(&handPose.BoneRotations))[:],
+		pose.BoneRotations)
+	for i, confidence := range pose.FingerConfidences {
+		handPose.FingerConfidences[i] = C.ovrConfidence(confidence)
+	}
+}
+
+// Bone is a bone of the skeleton vrapi_GetHandSkeleton reports, with the
+// orientation in the runtime's x, y, z, w order.
+type Bone struct {
+	Orientation [4]float32
+	Position    [3]float32
+	Parent      int
+}
+
+// Capsule is a collision capsule of the skeleton vrapi_GetHandSkeleton
+// reports.
+type Capsule struct {
+	Bone   int
+	Points [2][3]float32
+	Radius float32
+}
+
+// SetHandSkeleton sets the skeleton of handedness, an ovrHandedness.
+func SetHandSkeleton(handedness int, bones []Bone, capsules []Capsule) {
+	skeleton := &C.fake_script.HandSkeletons[handedness-1]
+	if len(bones) > len(skeleton.BonePoses) || len(capsules) > len(skeleton.Capsules) {
+		panic("fakevrapi: too many bones or capsules")
+	}
+	skeleton.NumBones = C.uint32_t(len(bones))
+	skeleton.NumCapsules = C.uint32_t(len(capsules))
+	for i, bone := range bones {
+		skeleton.BonePoses[i] = cPose(bone.Orientation, bone.Position)
+		skeleton.BoneParentIndices[i] = C.ovrHandBoneIndex(bone.Parent)
+	}
+	for i, capsule := range capsules {
+		skeleton.Capsules[i] = C.ovrBoneCapsule{
+			BoneIndex: C.ovrHandBoneIndex(capsule.Bone),
+			Points:    [2]C.ovrVector3f{cVector3f(capsule.Points[0]), cVector3f(capsule.Points[1])},
+			Radius:    C.float(capsule.Radius),
+		}
+	}
+}
+
+// HandMesh is the mesh vrapi_GetHandMesh reports. Every vertex array is as
+// long as Positions.
+type HandMesh struct {
+	Positions    [][3]float32
+	Normals      [][3]float32
+	UV0          [][2]float32
+	BlendIndices [][4]int16
+	BlendWeights [][4]float32
+	Indices      []int16
+}
+
+// SetHandMesh sets the mesh of handedness, an ovrHandedness.
+func SetHandMesh(handedness int, mesh HandMesh) {
+	cMesh := &C.fake_script.HandMeshes[handedness-1]
+	const maxVertices, maxIndices = len(cMesh.VertexPositions), len(cMesh.Indices)
+	if len(mesh.Positions) > maxVertices || len(mesh.Indices) > maxIndices {
+		panic("fakevrapi: too many vertices or indices")
+	}
+	cMesh.NumVertices = C.uint32_t(len(mesh.Positions))
+	cMesh.NumIndices = C.uint32_t(len(mesh.Indices))
+	copy((*[maxVertices][3]float32)(unsafe.Pointer(&cMesh.VertexPositions))[:], mesh.Positions)
+	copy((*[maxVertices][3]float32)(unsafe.Pointer(&cMesh.VertexNormals))[:], mesh.Normals)
+	copy((*[maxVertices][2]float32)(unsafe.Pointer(&cMesh.VertexUV0))[:], mesh.UV0)
+	copy((*[maxVertices][4]int16)(unsafe.Pointer(&cMesh.BlendIndices))[:], mesh.BlendIndices)
+	copy((*[maxVertices][4]float32)(unsafe.Pointer(&cMesh.BlendWeights))[:], mesh.BlendWeights)
+	copy((*[maxIndices]int16)(unsafe.Pointer(&cMesh.Indices))[:], mesh.Indices)
+}
+
+// SetHandCounts overrides every count in the skeleton and mesh of
+// handedness with count, like a runtime reporting more than the arrays
+// hold.
+func SetHandCounts(handedness int, count uint32) {
+	skeleton := &C.fake_script.HandSkeletons[handedness-1]
+	skeleton.NumBones = C.uint32_t(count)
+	skeleton.NumCapsules = C.uint32_t(count)
+	mesh := &C.fake_script.HandMeshes[handedness-1]
+	mesh.NumVertices = C.uint32_t(count)
+	mesh.NumIndices = C.uint32_t(count)
+}
+
+func inputDevice(deviceID uint32) *C.fakeInputDevice {
+	for i := C.int(0); i < C.fake_script.InputDeviceCount; i++ {
+		if device := &C.fake_script.InputDevices[i]; device.DeviceID == C.ovrDeviceID(deviceID) {
+			return device
+		}
+	}
+	panic("fakevrapi: no input device with that ID")
+}
+
+// TriggerResult is what vrapi_GetBoundaryTriggerState reports for a device.
+type TriggerResult struct {
+	ClosestPoint       [3]float32
+	ClosestPointNormal [3]float32
+	ClosestDistance    float32
+	IsTriggering       bool
+}
+
+// SetBoundaryTrigger makes vrapi_GetBoundaryTriggerState report result for
+// device, an ovrTrackedDeviceTypeId. Devices without a result fail with
+// ovrError_NoDevice.
+func SetBoundaryTrigger(device int, result TriggerResult) {
+	C.fake_script.TriggerTracked[device] = 1
+	C.fake_script.TriggerResults[device] = C.ovrBoundaryTriggerResult{
+		ClosestPoint:       cVector3f(result.ClosestPoint),
+		ClosestPointNormal: cVector3f(result.ClosestPointNormal),
+		ClosestDistance:    C.float(result.ClosestDistance),
+		IsTriggering:       C.bool(result.IsTriggering),
+	}
+}
+
+func cVector3f(v [3]float32) C.ovrVector3f {
+	return C.ovrVector3f{x: C.float(v[0]), y: C.float(v[1]), z: C.float(v[2])}
+}
+
+func cPose(orientation [4]float32, position [3]float32) C.ovrPosef {
+	var pose C.ovrPosef
+	*(*[4]float32)(unsafe.Pointer(&pose.Orientation)) = orientation
+	*(*[3]float32)(unsafe.Pointer(&pose.anon0)) = position
+	return pose
+}
+
+func cBool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// LastSubmit returns what the last vrapi_SubmitFrame2 received.
+func LastSubmit() Submit {
+	desc := &C.fake_lastSubmit.Desc
+	submit := Submit{
+		Flags:        uint32(desc.Flags),
+		SwapInterval: uint32(desc.SwapInterval),
+		FrameIndex:   uint64(desc.FrameIndex),
+		DisplayTime:  float64(desc.DisplayTime),
+	}
+	for i := 0; i < int(desc.LayerCount); i++ {
+		layer := Layer{Ptr: uintptr(unsafe.Pointer(C.fake_lastSubmit.Layers[i]))}
+		if copied := C.fake_lastSubmit.LayerCopies[i]; copied != nil {
+			size := C.int(C.fake_lastSubmit.LayerSizes[i])
+			layer.Bytes = C.GoBytes(unsafe.Pointer(copied), size)
+			layer.Type = int((*C.ovrLayerHeader2)(unsafe.Pointer(copied)).Type)
+		}
+		submit.Layers = append(submit.Layers, layer)
+	}
+	return submit
+}
+
+// SwapChainHandle returns the GL handle the fake reports for a swap chain
+// buffer. swapChain is the address of the C swap chain.
+func SwapChainHandle(swapChain uintptr, index int) uint32 {
+	return uint32(C.fake_swapChainHandle(C.uintptr_t(swapChain), C.int(index)))
+}
+
+// PredictedDisplayTime returns the display time the fake predicts for a
+// frame.
+func PredictedDisplayTime(frameIndex int64) float64 {
+	return float64(frameIndex) / DisplayRate
+}
@@ -0,0 +1,113 @@
+#ifndef FAKEVRAPI_H
+#define FAKEVRAPI_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#include <VrApi.h>
+#include <VrApi_Input.h>
+#include <VrApi_Vulkan.h>
+
+// Display refresh the fake predicts display times with.
+#define FAKE_DISPLAY_RATE 72.0
+
+#define FAKE_MAX_BOUNDARY_POINTS 64
+#define FAKE_MAX_INPUT_DEVICES 8
+#define FAKE_MAX_EXTENSIONS_SIZE 256
+
+typedef struct {
+	int Initialized;
+	// Sessions between vrapi_EnterVrMode and vrapi_LeaveVrMode.
+	int LiveSessions;
+	int LiveSwapChains;
+	int Submits;
+	// Calls the real runtime would crash or misbehave on, LastMisuse
+	// describes the latest.
+	int Misuses;
+	char LastMisuse[256];
+	// VRAPI_FOVEATION_LEVEL and VRAPI_DYNAMIC_FOVEATION_ENABLED as last set.
+	int FoveationLevel;
+	int DynamicFoveation;
+	ovrTrackingSpace TrackingSpace;
+	// The target of the last vrapi_LocateTrackingSpace.
+	ovrTrackingSpace LocatedSpace;
+	// Calls to vrapi_GetInputDeviceCapabilities and vrapi_GetCurrentInputState.
+	int InputCalls;
+	// The time passed to the last vrapi_GetInputTrackingState.
+	double InputTrackingTime;
+	// Between vrapi_CreateSystemVulkan and vrapi_DestroySystemVulkan, with
+	// the handles it was last given.
+	int VulkanSystem;
+	uintptr_t VulkanInstance;
+	uintptr_t VulkanPhysicalDevice;
+	uintptr_t VulkanDevice;
+	// The queue in the Vulkan mode parms of the last vrapi_EnterVrMode, 0
+	// after GLES ones.
+	unsigned long long SynchronizationQueue;
+} fakeState;
+
+// An input device as vrapi_EnumerateInputDevices reports it.
+typedef struct {
+	ovrControllerType Type;
+	ovrDeviceID DeviceID;
+	// Copied over the caller's capabilities and input state structs, which
+	// are as large as Type says. The headers are left as the caller set them.
+	unsigned char Capabilities[512];
+	unsigned char State[512];
+	ovrTracking Tracking;
+	// For hands, the header is left as the caller set it and
+	// RequestedTimeStamp is the time asked for.
+	ovrHandPose HandPose;
+} fakeInputDevice;
+
+// What the fake reports, set by tests before the calls that read it.
+typedef struct {
+	int FoveationAvailable;
+	// Returned by vrapi_LocateTrackingSpace for any target.
+	ovrPosef LocatedPose;
+
+	// Without a valid boundary the boundary functions return
+	// ovrSuccess_BoundaryInvalid.
+	int BoundaryValid;
+	ovrVector3f BoundaryPoints[FAKE_MAX_BOUNDARY_POINTS];
+	uint32_t BoundaryPointCount;
+	// If set the boundary is replaced by ChangedPoints right after the next
+	// vrapi_GetBoundaryGeometry that only asks for the count.
+	int BoundaryChanged;
+	ovrVector3f ChangedPoints[FAKE_MAX_BOUNDARY_POINTS];
+	uint32_t ChangedPointCount;
+	// Indexed by ovrTrackedDeviceTypeId, untracked devices fail with
+	// ovrError_NoDevice.
+	int TriggerTracked[VRAPI_NUM_TRACKED_DEVICES];
+	ovrBoundaryTriggerResult TriggerResults[VRAPI_NUM_TRACKED_DEVICES];
+
+	fakeInputDevice InputDevices[FAKE_MAX_INPUT_DEVICES];
+	int InputDeviceCount;
+
+	// Indexed by ovrHandedness - 1, the headers are left as the caller set
+	// them.
+	ovrHandSkeleton HandSkeletons[2];
+	ovrHandMesh HandMeshes[2];
+
+	// Space delimited like the runtime reports them.
+	char InstanceExtensions[FAKE_MAX_EXTENSIONS_SIZE];
+	char DeviceExtensions[FAKE_MAX_EXTENSIONS_SIZE];
+} fakeScript;
+
+// A copy of what the last vrapi_SubmitFrame2 received.
+typedef struct {
+	ovrSubmitFrameDescription2 Desc;
+	// Desc.Layers[i] and a copy of the layer it pointed at.
+	const ovrLayerHeader2* Layers[ovrMaxLayerCount];
+	unsigned char* LayerCopies[ovrMaxLayerCount];
+	size_t LayerSizes[ovrMaxLayerCount];
+} fakeSubmit;
+
+extern fakeState fake_state;
+extern fakeSubmit fake_lastSubmit;
+extern fakeScript fake_script;
+
+void fake_reset(void);
+unsigned int fake_swapChainHandle(uintptr_t chain, int index);
+
+#endif
@@ -0,0 +1,244 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <VrApi.h>
+#include <VrApi_Helpers.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Layer types other than projection. All of these are submitted through
+// SubmitFrame2 by passing a pointer to the layer, see Layer.
+
+type OVRLayerCylinder2 struct {
+	Header OVRLayerHeader2
+	_      padding32Bit
+
+	HeadPose OVRRigidBodyPosef
+
+	Textures [FRAME_LAYER_EYE_MAX]CylinderEyeInformation
+}
+
+type CylinderEyeInformation struct {
+	// Texture type used to create the swapchain must be a 2D target (TEXTURE_TYPE_2D_*).
+	ColorSwapChain         OVRTextureSwapChain
+	SwapChainIndex         int32
+	TexCoordsFromTanAngles mgl.Mat4
+	TextureRect            OVRRectf
+	// Set up like the following since z is not needed for mapping to a 2d texture.
+	// sx,  0, tx, 0
+	// 0,  sy, ty, 0
+	// 0,   0,  1, 0
+	// 0,   0,  0, 1
+	TextureMatrix mgl.Mat4
+}
+
+type OVRLayerCube2 struct {
+	Header OVRLayerHeader2
+	_      padding32Bit
+
+	HeadPose               OVRRigidBodyPosef
+	TexCoordsFromTanAngles mgl.Mat4
+
+	Offset mgl.Vec3 // In normalized [-1.0,1.0] space.
+
+	// Textures, embedded along with the padding C adds after them on 32 bit
+	// ARM. See layout_arm.go.
+	cubeTextures
+}
+
+type CubeEyeInformation struct {
+	// Texture type used to create the swapchain must be a cube target (TEXTURE_TYPE_CUBE).
+	ColorSwapChain OVRTextureSwapChain
+	SwapChainIndex int32
+}
+
+type OVRLayerEquirect2 struct {
+	Header OVRLayerHeader2
+	_      padding32Bit
+
+	HeadPose               OVRRigidBodyPosef
+	TexCoordsFromTanAngles mgl.Mat4
+
+	Textures [FRAME_LAYER_EYE_MAX]EquirectEyeInformation
+}
+
+type EquirectEyeInformation struct {
+	// Texture type used to create the swapchain must be a 2D target (TEXTURE_TYPE_2D_*).
+	ColorSwapChain OVRTextureSwapChain
+	SwapChainIndex int32
+	TextureRect    OVRRectf
+	TextureMatrix  mgl.Mat4
+}
+
+// Like OVRLayerEquirect2 but TexCoordsFromTanAngles is per eye and can place
+// the equirect at a non infinite radius. See VrApi_Types.h for details.
+type OVRLayerEquirect3 struct {
+	Header OVRLayerHeader2
+	_      padding32Bit
+
+	HeadPose OVRRigidBodyPosef
+
+	Textures [FRAME_LAYER_EYE_MAX]Equirect3EyeInformation
+}
+
+type Equirect3EyeInformation struct {
+	// Texture type used to create the swapchain must be a 2D target (TEXTURE_TYPE_2D_*).
+	ColorSwapChain OVRTextureSwapChain
+	SwapChainIndex int32
+	// M[3][0..2] is the translation of the center and M[3][3] the radius in meters
+	// (0.0 is infinite radius).
+	TexCoordsFromTanAngles mgl.Mat4
+	TextureRect            OVRRectf
+	TextureMatrix          mgl.Mat4
+}
+
+// Monoscopic spinning layer.
+type OVRLayerLoadingIcon2 struct {
+	Header OVRLayerHeader2
+
+	SpinSpeed float32 // Radians per second
+	SpinScale float32
+
+	ColorSwapChain OVRTextureSwapChain
+	SwapChainIndex int32
+}
+
+type OVRLayerFishEye2 struct {
+	Header OVRLayerHeader2
+	_      padding32Bit
+
+	HeadPose OVRRigidBodyPosef
+
+	Textures [FRAME_LAYER_EYE_MAX]FishEyeEyeInformation
+}
+
+type FishEyeEyeInformation struct {
+	ColorSwapChain    OVRTextureSwapChain
+	SwapChainIndex    int32
+	LensFromTanAngles mgl.Mat4 // Transforms a tanAngle ray into lens space
+	TextureRect       OVRRectf // Packed stereo images will need to clamp at the mid border
+	TextureMatrix     mgl.Mat4 // Transform from a -1 to 1 ideal fisheye to the texture
+	Distortion        mgl.Vec4 // Not currently used.
+}
+
+func DefaultLayerCylinder2() OVRLayerCylinder2 {
+	cLayer := C.vrapi_DefaultLayerCylinder2()
+	return *(*OVRLayerCylinder2)(unsafe.Pointer(&cLayer))
+}
+
+func DefaultLayerCube2() OVRLayerCube2 {
+	cLayer := C.vrapi_DefaultLayerCube2()
+	return *(*OVRLayerCube2)(unsafe.Pointer(&cLayer))
+}
+
+func DefaultLayerEquirect2() OVRLayerEquirect2 {
+	cLayer := C.vrapi_DefaultLayerEquirect2()
+	return *(*OVRLayerEquirect2)(unsafe.Pointer(&cLayer))
+}
+
+func DefaultLayerEquirect3() OVRLayerEquirect3 {
+	cLayer := C.vrapi_DefaultLayerEquirect3()
+	return *(*OVRLayerEquirect3)(unsafe.Pointer(&cLayer))
+}
+
+// The default loading icon layer uses the runtime's built in loading icon
+// texture, DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON, so it can be submitted
+// without creating a swap chain.
+func DefaultLayerLoadingIcon2() OVRLayerLoadingIcon2 {
+	cLayer := C.vrapi_DefaultLayerLoadingIcon2()
+	return *(*OVRLayerLoadingIcon2)(unsafe.Pointer(&cLayer))
+}
+
+func DefaultLayerFishEye2() OVRLayerFishEye2 {
+	cLayer := C.vrapi_DefaultLayerFishEye2()
+	return *(*OVRLayerFishEye2)(unsafe.Pointer(&cLayer))
+}
+
+// Layer implementations.
+
+func (l *OVRLayerProjection2) header() *OVRLayerHeader2  { return &l.Header }
+func (l *OVRLayerCylinder2) header() *OVRLayerHeader2    { return &l.Header }
+func (l *OVRLayerCube2) header() *OVRLayerHeader2        { return &l.Header }
+func (l *OVRLayerEquirect2) header() *OVRLayerHeader2    { return &l.Header }
+func (l *OVRLayerEquirect3) header() *OVRLayerHeader2    { return &l.Header }
+func (l *OVRLayerLoadingIcon2) header() *OVRLayerHeader2 { return &l.Header }
+func (l *OVRLayerFishEye2) header() *OVRLayerHeader2     { return &l.Header }
+
+func (l *OVRLayerProjection2) size() uintptr  { return unsafe.Sizeof(*l) }
+func (l *OVRLayerCylinder2) size() uintptr    { return unsafe.Sizeof(*l) }
+func (l *OVRLayerCube2) size() uintptr        { return unsafe.Sizeof(*l) }
+func (l *OVRLayerEquirect2) size() uintptr    { return unsafe.Sizeof(*l) }
+func (l *OVRLayerEquirect3) size() uintptr    { return unsafe.Sizeof(*l) }
+func (l *OVRLayerLoadingIcon2) size() uintptr { return unsafe.Sizeof(*l) }
+func (l *OVRLayerFishEye2) size() uintptr     { return unsafe.Sizeof(*l) }
+
+func (l *OVRLayerProjection2) swapChains() []OVRTextureSwapChain {
+	var swapChains []OVRTextureSwapChain
+	for _, eye := range l.Textures {
+		swapChains = appendSwapChain(swapChains, eye.ColorSwapChain)
+	}
+	return swapChains
+}
+
+func (l *OVRLayerCylinder2) swapChains() []OVRTextureSwapChain {
+	var swapChains []OVRTextureSwapChain
+	for _, eye := range l.Textures {
+		swapChains = appendSwapChain(swapChains, eye.ColorSwapChain)
+	}
+	return swapChains
+}
+
+func (l *OVRLayerCube2) swapChains() []OVRTextureSwapChain {
+	var swapChains []OVRTextureSwapChain
+	for _, eye := range l.Textures {
+		swapChains = appendSwapChain(swapChains, eye.ColorSwapChain)
+	}
+	return swapChains
+}
+
+func (l *OVRLayerEquirect2) swapChains() []OVRTextureSwapChain {
+	var swapChains []OVRTextureSwapChain
+	for _, eye := range l.Textures {
+		swapChains = appendSwapChain(swapChains, eye.ColorSwapChain)
+	}
+	return swapChains
+}
+
+func (l *OVRLayerEquirect3) swapChains() []OVRTextureSwapChain {
+	var swapChains []OVRTextureSwapChain
+	for _, eye := range l.Textures {
+		swapChains = appendSwapChain(swapChains, eye.ColorSwapChain)
+	}
+	return swapChains
+}
+
+func (l *OVRLayerLoadingIcon2) swapChains() []OVRTextureSwapChain {
+	return appendSwapChain(nil, l.ColorSwapChain)
+}
+
+func (l *OVRLayerFishEye2) swapChains() []OVRTextureSwapChain {
+	var swapChains []OVRTextureSwapChain
+	for _, eye := range l.Textures {
+		swapChains = appendSwapChain(swapChains, eye.ColorSwapChain)
+	}
+	return swapChains
+}
+
+// appendSwapChain skips eyes without a swap chain or with one of the
+// runtime's built in swap chains, which have nothing to check.
+func appendSwapChain(swapChains []OVRTextureSwapChain,
+	swapChain OVRTextureSwapChain) []OVRTextureSwapChain {
+
+	if swapChain != 0 && !IsDefaultTextureSwapChain(swapChain) {
+		swapChains = append(swapChains, swapChain)
+	}
+	return swapChains
+}
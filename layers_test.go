@@ -0,0 +1,48 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestDefaultLayerLoadingIcon2(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	black := DefaultLayerBlackProjection2()
+	icon := DefaultLayerLoadingIcon2()
+	growStack(1000)
+
+	if icon.ColorSwapChain != DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON {
+		t.Errorf("swap chain %#x, want DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON",
+			icon.ColorSwapChain)
+	}
+	if icon.Header.Type != LAYER_TYPE_LOADING_ICON2 {
+		t.Errorf("layer type %d, want LAYER_TYPE_LOADING_ICON2", icon.Header.Type)
+	}
+	if icon.SpinSpeed != 1 || icon.SpinScale != 16 {
+		t.Errorf("spin speed %v scale %v, want 1 and 16", icon.SpinSpeed, icon.SpinScale)
+	}
+
+	// What vrapi_DefaultFrameParms submits while loading.
+	frameDesc := OVRSubmitFrameDescription2{LayerCount: 2, Layers: []Layer{&black, &icon}}
+	if err := c.SubmitFrame2(vrApp, &frameDesc); err != nil {
+		t.Fatalf("SubmitFrame2: %v", err)
+	}
+	submitted := fakevrapi.LastSubmit().Layers
+	if len(submitted) != 2 {
+		t.Fatalf("submitted %d layers, want 2", len(submitted))
+	}
+	if !bytes.Equal(submitted[1].Bytes, layerBytes(&icon)) {
+		t.Error("runtime saw different loading icon bytes than submitted")
+	}
+
+	if _, err := c.GetTextureSwapChainHandle(icon.ColorSwapChain, 0); err == nil {
+		t.Error("GetTextureSwapChainHandle(DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON) returned nil error")
+	}
+}
@@ -0,0 +1,315 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <stddef.h>
+#include <VrApi.h>
+#include <VrApi_Input.h>
+#include <VrApi_Vulkan.h>
+
+// Offsets of fields in the C structs so they can be compared against
+// the Go mirrors at compile time.
+enum {
+	offsetof_ovrModeParms_Flags = offsetof(ovrModeParms, Flags),
+	offsetof_ovrModeParms_Java = offsetof(ovrModeParms, Java),
+	offsetof_ovrModeParms_Display = offsetof(ovrModeParms, Display),
+	offsetof_ovrModeParms_WindowSurface = offsetof(ovrModeParms, WindowSurface),
+	offsetof_ovrModeParms_ShareContext = offsetof(ovrModeParms, ShareContext),
+
+	offsetof_ovrPosef_Position = offsetof(ovrPosef, Position),
+
+	offsetof_ovrRectf_width = offsetof(ovrRectf, width),
+	offsetof_ovrRectf_height = offsetof(ovrRectf, height),
+
+	offsetof_ovrRigidBodyPosef_AngularVelocity = offsetof(ovrRigidBodyPosef, AngularVelocity),
+	offsetof_ovrRigidBodyPosef_LinearVelocity = offsetof(ovrRigidBodyPosef, LinearVelocity),
+	offsetof_ovrRigidBodyPosef_AngularAcceleration = offsetof(ovrRigidBodyPosef, AngularAcceleration),
+	offsetof_ovrRigidBodyPosef_LinearAcceleration = offsetof(ovrRigidBodyPosef, LinearAcceleration),
+	offsetof_ovrRigidBodyPosef_TimeInSeconds = offsetof(ovrRigidBodyPosef, TimeInSeconds),
+	offsetof_ovrRigidBodyPosef_PredictionInSeconds = offsetof(ovrRigidBodyPosef, PredictionInSeconds),
+
+	offsetof_ovrTracking2_HeadPose = offsetof(ovrTracking2, HeadPose),
+	offsetof_ovrTracking2_Eye = offsetof(ovrTracking2, Eye),
+	offsetof_ovrTracking2_Eye_ViewMatrix = offsetof(ovrTracking2, Eye[0].ViewMatrix),
+
+	offsetof_ovrLayerHeader2_Flags = offsetof(ovrLayerHeader2, Flags),
+	offsetof_ovrLayerHeader2_ColorScale = offsetof(ovrLayerHeader2, ColorScale),
+	offsetof_ovrLayerHeader2_SrcBlend = offsetof(ovrLayerHeader2, SrcBlend),
+	offsetof_ovrLayerHeader2_DstBlend = offsetof(ovrLayerHeader2, DstBlend),
+	offsetof_ovrLayerHeader2_Reserved = offsetof(ovrLayerHeader2, Reserved),
+
+	offsetof_ovrLayerProjection2_HeadPose = offsetof(ovrLayerProjection2, HeadPose),
+	offsetof_ovrLayerProjection2_Textures = offsetof(ovrLayerProjection2, Textures),
+	offsetof_ovrLayerProjection2_Textures_SwapChainIndex = offsetof(ovrLayerProjection2, Textures[0].SwapChainIndex),
+	offsetof_ovrLayerProjection2_Textures_TexCoordsFromTanAngles = offsetof(ovrLayerProjection2, Textures[0].TexCoordsFromTanAngles),
+	offsetof_ovrLayerProjection2_Textures_TextureRect = offsetof(ovrLayerProjection2, Textures[0].TextureRect),
+
+	offsetof_ovrLayerCylinder2_HeadPose = offsetof(ovrLayerCylinder2, HeadPose),
+	offsetof_ovrLayerCylinder2_Textures = offsetof(ovrLayerCylinder2, Textures),
+	offsetof_ovrLayerCylinder2_Textures_TextureRect = offsetof(ovrLayerCylinder2, Textures[0].TextureRect),
+	offsetof_ovrLayerCylinder2_Textures_TextureMatrix = offsetof(ovrLayerCylinder2, Textures[0].TextureMatrix),
+
+	offsetof_ovrLayerCube2_HeadPose = offsetof(ovrLayerCube2, HeadPose),
+	offsetof_ovrLayerCube2_TexCoordsFromTanAngles = offsetof(ovrLayerCube2, TexCoordsFromTanAngles),
+	offsetof_ovrLayerCube2_Offset = offsetof(ovrLayerCube2, Offset),
+	offsetof_ovrLayerCube2_Textures = offsetof(ovrLayerCube2, Textures),
+	offsetof_ovrLayerCube2_Textures_SwapChainIndex = offsetof(ovrLayerCube2, Textures[0].SwapChainIndex),
+
+	offsetof_ovrLayerEquirect2_HeadPose = offsetof(ovrLayerEquirect2, HeadPose),
+	offsetof_ovrLayerEquirect2_TexCoordsFromTanAngles = offsetof(ovrLayerEquirect2, TexCoordsFromTanAngles),
+	offsetof_ovrLayerEquirect2_Textures = offsetof(ovrLayerEquirect2, Textures),
+	offsetof_ovrLayerEquirect2_Textures_TextureRect = offsetof(ovrLayerEquirect2, Textures[0].TextureRect),
+	offsetof_ovrLayerEquirect2_Textures_TextureMatrix = offsetof(ovrLayerEquirect2, Textures[0].TextureMatrix),
+
+	offsetof_ovrLayerEquirect3_HeadPose = offsetof(ovrLayerEquirect3, HeadPose),
+	offsetof_ovrLayerEquirect3_Textures = offsetof(ovrLayerEquirect3, Textures),
+	offsetof_ovrLayerEquirect3_Textures_TextureRect = offsetof(ovrLayerEquirect3, Textures[0].TextureRect),
+	offsetof_ovrLayerEquirect3_Textures_TextureMatrix = offsetof(ovrLayerEquirect3, Textures[0].TextureMatrix),
+
+	offsetof_ovrLayerLoadingIcon2_SpinSpeed = offsetof(ovrLayerLoadingIcon2, SpinSpeed),
+	offsetof_ovrLayerLoadingIcon2_SpinScale = offsetof(ovrLayerLoadingIcon2, SpinScale),
+	offsetof_ovrLayerLoadingIcon2_ColorSwapChain = offsetof(ovrLayerLoadingIcon2, ColorSwapChain),
+	offsetof_ovrLayerLoadingIcon2_SwapChainIndex = offsetof(ovrLayerLoadingIcon2, SwapChainIndex),
+
+	offsetof_ovrLayerFishEye2_HeadPose = offsetof(ovrLayerFishEye2, HeadPose),
+	offsetof_ovrLayerFishEye2_Textures = offsetof(ovrLayerFishEye2, Textures),
+	offsetof_ovrLayerFishEye2_Textures_TextureRect = offsetof(ovrLayerFishEye2, Textures[0].TextureRect),
+	offsetof_ovrLayerFishEye2_Textures_TextureMatrix = offsetof(ovrLayerFishEye2, Textures[0].TextureMatrix),
+	offsetof_ovrLayerFishEye2_Textures_Distortion = offsetof(ovrLayerFishEye2, Textures[0].Distortion),
+
+	offsetof_ovrSwapChainCreateInfo_Width = offsetof(ovrSwapChainCreateInfo, Width),
+	offsetof_ovrSwapChainCreateInfo_BufferCount = offsetof(ovrSwapChainCreateInfo, BufferCount),
+	offsetof_ovrSwapChainCreateInfo_CreateFlags = offsetof(ovrSwapChainCreateInfo, CreateFlags),
+	offsetof_ovrSwapChainCreateInfo_UsageFlags = offsetof(ovrSwapChainCreateInfo, UsageFlags),
+
+	offsetof_ovrInputCapabilityHeader_DeviceID = offsetof(ovrInputCapabilityHeader, DeviceID),
+
+	offsetof_ovrInputStateHeader_TimeInSeconds = offsetof(ovrInputStateHeader, TimeInSeconds),
+
+	offsetof_ovrInputStateTrackedRemote_Buttons = offsetof(ovrInputStateTrackedRemote, Buttons),
+	offsetof_ovrInputStateTrackedRemote_TrackpadStatus = offsetof(ovrInputStateTrackedRemote, TrackpadStatus),
+	offsetof_ovrInputStateTrackedRemote_TrackpadPosition = offsetof(ovrInputStateTrackedRemote, TrackpadPosition),
+	offsetof_ovrInputStateTrackedRemote_BatteryPercentRemaining = offsetof(ovrInputStateTrackedRemote, BatteryPercentRemaining),
+	offsetof_ovrInputStateTrackedRemote_RecenterCount = offsetof(ovrInputStateTrackedRemote, RecenterCount),
+	offsetof_ovrInputStateTrackedRemote_IndexTrigger = offsetof(ovrInputStateTrackedRemote, IndexTrigger),
+	offsetof_ovrInputStateTrackedRemote_GripTrigger = offsetof(ovrInputStateTrackedRemote, GripTrigger),
+	offsetof_ovrInputStateTrackedRemote_Touches = offsetof(ovrInputStateTrackedRemote, Touches),
+	offsetof_ovrInputStateTrackedRemote_Joystick = offsetof(ovrInputStateTrackedRemote, Joystick),
+	offsetof_ovrInputStateTrackedRemote_JoystickNoDeadZone = offsetof(ovrInputStateTrackedRemote, JoystickNoDeadZone),
+
+	offsetof_ovrInputStateStandardPointer_PointerPose = offsetof(ovrInputStateStandardPointer, PointerPose),
+	offsetof_ovrInputStateStandardPointer_PointerStrength = offsetof(ovrInputStateStandardPointer, PointerStrength),
+	offsetof_ovrInputStateStandardPointer_GripPose = offsetof(ovrInputStateStandardPointer, GripPose),
+	offsetof_ovrInputStateStandardPointer_InputStateStatus = offsetof(ovrInputStateStandardPointer, InputStateStatus),
+	offsetof_ovrInputStateStandardPointer_Reserved = offsetof(ovrInputStateStandardPointer, Reserved),
+
+	offsetof_ovrInputStandardPointerCapabilities_ControllerCapabilities = offsetof(ovrInputStandardPointerCapabilities, ControllerCapabilities),
+	offsetof_ovrInputStandardPointerCapabilities_HapticSamplesMax = offsetof(ovrInputStandardPointerCapabilities, HapticSamplesMax),
+	offsetof_ovrInputStandardPointerCapabilities_HapticSampleDurationMS = offsetof(ovrInputStandardPointerCapabilities, HapticSampleDurationMS),
+	offsetof_ovrInputStandardPointerCapabilities_Reserved = offsetof(ovrInputStandardPointerCapabilities, Reserved),
+	offsetof_ovrSystemCreateInfoVulkan_PhysicalDevice = offsetof(ovrSystemCreateInfoVulkan, PhysicalDevice),
+	offsetof_ovrSystemCreateInfoVulkan_Device = offsetof(ovrSystemCreateInfoVulkan, Device),
+	offsetof_ovrModeParmsVulkan_SynchronizationQueue = offsetof(ovrModeParmsVulkan, SynchronizationQueue),
+	offsetof_ovrBoundaryTriggerResult_ClosestPointNormal = offsetof(ovrBoundaryTriggerResult, ClosestPointNormal),
+	offsetof_ovrBoundaryTriggerResult_ClosestDistance = offsetof(ovrBoundaryTriggerResult, ClosestDistance),
+	offsetof_ovrBoundaryTriggerResult_IsTriggering = offsetof(ovrBoundaryTriggerResult, IsTriggering),
+	offsetof_ovrTracking_HeadPose = offsetof(ovrTracking, HeadPose),
+	offsetof_ovrInputHandCapabilities_HandCapabilities = offsetof(ovrInputHandCapabilities, HandCapabilities),
+	offsetof_ovrInputHandCapabilities_StateCapabilities = offsetof(ovrInputHandCapabilities, StateCapabilities),
+	offsetof_ovrInputStateHand_PinchStrength = offsetof(ovrInputStateHand, PinchStrength),
+	offsetof_ovrInputStateHand_PointerPose = offsetof(ovrInputStateHand, PointerPose),
+	offsetof_ovrInputStateHand_InputStateStatus = offsetof(ovrInputStateHand, InputStateStatus),
+	offsetof_ovrInputTrackedRemoteCapabilities_ControllerCapabilities = offsetof(ovrInputTrackedRemoteCapabilities, ControllerCapabilities),
+	offsetof_ovrInputTrackedRemoteCapabilities_ButtonCapabilities = offsetof(ovrInputTrackedRemoteCapabilities, ButtonCapabilities),
+	offsetof_ovrInputTrackedRemoteCapabilities_TrackpadMaxX = offsetof(ovrInputTrackedRemoteCapabilities, TrackpadMaxX),
+	offsetof_ovrInputTrackedRemoteCapabilities_TrackpadMaxY = offsetof(ovrInputTrackedRemoteCapabilities, TrackpadMaxY),
+	offsetof_ovrInputTrackedRemoteCapabilities_TrackpadSizeX = offsetof(ovrInputTrackedRemoteCapabilities, TrackpadSizeX),
+	offsetof_ovrInputTrackedRemoteCapabilities_TrackpadSizeY = offsetof(ovrInputTrackedRemoteCapabilities, TrackpadSizeY),
+	offsetof_ovrInputTrackedRemoteCapabilities_HapticSamplesMax = offsetof(ovrInputTrackedRemoteCapabilities, HapticSamplesMax),
+	offsetof_ovrInputTrackedRemoteCapabilities_HapticSampleDurationMS = offsetof(ovrInputTrackedRemoteCapabilities, HapticSampleDurationMS),
+	offsetof_ovrInputTrackedRemoteCapabilities_TouchCapabilities = offsetof(ovrInputTrackedRemoteCapabilities, TouchCapabilities),
+	offsetof_ovrInputTrackedRemoteCapabilities_Reserved4 = offsetof(ovrInputTrackedRemoteCapabilities, Reserved4),
+	offsetof_ovrInputTrackedRemoteCapabilities_Reserved5 = offsetof(ovrInputTrackedRemoteCapabilities, Reserved5),
+};
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Every Go struct that gets cast to or from a C struct through unsafe.Pointer
+// is checked here. If a size or offset does not match the C definition then
+// the index into the one element array is out of range (or negative) and
+// the package fails to compile. Since C.sizeof_ and offsetof come from the C
+// compiler for the target, building for 32 bit ARM (GOARCH=arm) and 64 bit
+// ARM (GOARCH=arm64) checks the layout of each. A linux host build against
+// the bundled Include headers checks the 64 bit layout, layout_test.go lays
+// out the headers for both and checks the size and offset of every field.
+//
+// OVRSubmitFrameDescription2 is not listed since SubmitFrame2 copies it
+// field by field. OVRJava and OVRInitParms are the C types themselves.
+var (
+	_ = [1]struct{}{}[unsafe.Sizeof(mgl.Vec2{})-C.sizeof_ovrVector2f]
+	_ = [1]struct{}{}[unsafe.Sizeof(mgl.Vec3{})-C.sizeof_ovrVector3f]
+	_ = [1]struct{}{}[unsafe.Sizeof(mgl.Vec4{})-C.sizeof_ovrVector4f]
+	_ = [1]struct{}{}[unsafe.Sizeof(mgl.Quat{})-C.sizeof_ovrQuatf]
+	_ = [1]struct{}{}[unsafe.Sizeof(mgl.Mat4{})-C.sizeof_ovrMatrix4f]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRModeParms{})-C.sizeof_ovrModeParms]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRModeParms{}.Flags)-C.offsetof_ovrModeParms_Flags]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRModeParms{}.Java)-C.offsetof_ovrModeParms_Java]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRModeParms{}.Display)-C.offsetof_ovrModeParms_Display]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRModeParms{}.WindowSurface)-C.offsetof_ovrModeParms_WindowSurface]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRModeParms{}.ShareContext)-C.offsetof_ovrModeParms_ShareContext]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRPosef{})-C.sizeof_ovrPosef]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRPosef{}.Position)-C.offsetof_ovrPosef_Position]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRRectf{})-C.sizeof_ovrRectf]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRectf{}.Width)-C.offsetof_ovrRectf_width]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRectf{}.Height)-C.offsetof_ovrRectf_height]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRRigidBodyPosef{})-C.sizeof_ovrRigidBodyPosef]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRigidBodyPosef{}.AngularVelocity)-C.offsetof_ovrRigidBodyPosef_AngularVelocity]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRigidBodyPosef{}.LinearVelocity)-C.offsetof_ovrRigidBodyPosef_LinearVelocity]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRigidBodyPosef{}.AngularAcceleration)-C.offsetof_ovrRigidBodyPosef_AngularAcceleration]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRigidBodyPosef{}.LinearAcceleration)-C.offsetof_ovrRigidBodyPosef_LinearAcceleration]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRigidBodyPosef{}.TimeInSeconds)-C.offsetof_ovrRigidBodyPosef_TimeInSeconds]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRRigidBodyPosef{}.PredictionInSeconds)-C.offsetof_ovrRigidBodyPosef_PredictionInSeconds]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRTracking2{})-C.sizeof_ovrTracking2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRTracking2{}.HeadPose)-C.offsetof_ovrTracking2_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRTracking2{}.Eye)-C.offsetof_ovrTracking2_Eye]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRTracking2{}.Eye)+unsafe.Offsetof(Tracking2Matrices{}.ViewMatrix)-C.offsetof_ovrTracking2_Eye_ViewMatrix]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerHeader2{})-C.sizeof_ovrLayerHeader2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerHeader2{}.Flags)-C.offsetof_ovrLayerHeader2_Flags]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerHeader2{}.ColorScale)-C.offsetof_ovrLayerHeader2_ColorScale]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerHeader2{}.SrcBlend)-C.offsetof_ovrLayerHeader2_SrcBlend]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerHeader2{}.DstBlend)-C.offsetof_ovrLayerHeader2_DstBlend]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerHeader2{}.Reserved)-C.offsetof_ovrLayerHeader2_Reserved]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerProjection2{})-C.sizeof_ovrLayerProjection2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerProjection2{}.HeadPose)-C.offsetof_ovrLayerProjection2_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerProjection2{}.Textures)-C.offsetof_ovrLayerProjection2_Textures]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerProjection2{}.Textures)+unsafe.Offsetof(EyeInformation{}.SwapChainIndex)-C.offsetof_ovrLayerProjection2_Textures_SwapChainIndex]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerProjection2{}.Textures)+unsafe.Offsetof(EyeInformation{}.TexCoordsFromTanAngles)-C.offsetof_ovrLayerProjection2_Textures_TexCoordsFromTanAngles]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerProjection2{}.Textures)+unsafe.Offsetof(EyeInformation{}.TextureRect)-C.offsetof_ovrLayerProjection2_Textures_TextureRect]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerCylinder2{})-C.sizeof_ovrLayerCylinder2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCylinder2{}.HeadPose)-C.offsetof_ovrLayerCylinder2_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCylinder2{}.Textures)-C.offsetof_ovrLayerCylinder2_Textures]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCylinder2{}.Textures)+unsafe.Offsetof(CylinderEyeInformation{}.TextureRect)-C.offsetof_ovrLayerCylinder2_Textures_TextureRect]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCylinder2{}.Textures)+unsafe.Offsetof(CylinderEyeInformation{}.TextureMatrix)-C.offsetof_ovrLayerCylinder2_Textures_TextureMatrix]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerCube2{})-C.sizeof_ovrLayerCube2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCube2{}.HeadPose)-C.offsetof_ovrLayerCube2_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCube2{}.TexCoordsFromTanAngles)-C.offsetof_ovrLayerCube2_TexCoordsFromTanAngles]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCube2{}.Offset)-C.offsetof_ovrLayerCube2_Offset]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCube2{}.Textures)-C.offsetof_ovrLayerCube2_Textures]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerCube2{}.Textures)+unsafe.Offsetof(CubeEyeInformation{}.SwapChainIndex)-C.offsetof_ovrLayerCube2_Textures_SwapChainIndex]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerEquirect2{})-C.sizeof_ovrLayerEquirect2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect2{}.HeadPose)-C.offsetof_ovrLayerEquirect2_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect2{}.TexCoordsFromTanAngles)-C.offsetof_ovrLayerEquirect2_TexCoordsFromTanAngles]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect2{}.Textures)-C.offsetof_ovrLayerEquirect2_Textures]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect2{}.Textures)+unsafe.Offsetof(EquirectEyeInformation{}.TextureRect)-C.offsetof_ovrLayerEquirect2_Textures_TextureRect]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect2{}.Textures)+unsafe.Offsetof(EquirectEyeInformation{}.TextureMatrix)-C.offsetof_ovrLayerEquirect2_Textures_TextureMatrix]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerEquirect3{})-C.sizeof_ovrLayerEquirect3]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect3{}.HeadPose)-C.offsetof_ovrLayerEquirect3_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect3{}.Textures)-C.offsetof_ovrLayerEquirect3_Textures]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect3{}.Textures)+unsafe.Offsetof(Equirect3EyeInformation{}.TextureRect)-C.offsetof_ovrLayerEquirect3_Textures_TextureRect]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerEquirect3{}.Textures)+unsafe.Offsetof(Equirect3EyeInformation{}.TextureMatrix)-C.offsetof_ovrLayerEquirect3_Textures_TextureMatrix]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerLoadingIcon2{})-C.sizeof_ovrLayerLoadingIcon2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerLoadingIcon2{}.SpinSpeed)-C.offsetof_ovrLayerLoadingIcon2_SpinSpeed]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerLoadingIcon2{}.SpinScale)-C.offsetof_ovrLayerLoadingIcon2_SpinScale]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerLoadingIcon2{}.ColorSwapChain)-C.offsetof_ovrLayerLoadingIcon2_ColorSwapChain]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerLoadingIcon2{}.SwapChainIndex)-C.offsetof_ovrLayerLoadingIcon2_SwapChainIndex]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRLayerFishEye2{})-C.sizeof_ovrLayerFishEye2]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerFishEye2{}.HeadPose)-C.offsetof_ovrLayerFishEye2_HeadPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerFishEye2{}.Textures)-C.offsetof_ovrLayerFishEye2_Textures]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerFishEye2{}.Textures)+unsafe.Offsetof(FishEyeEyeInformation{}.TextureRect)-C.offsetof_ovrLayerFishEye2_Textures_TextureRect]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerFishEye2{}.Textures)+unsafe.Offsetof(FishEyeEyeInformation{}.TextureMatrix)-C.offsetof_ovrLayerFishEye2_Textures_TextureMatrix]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRLayerFishEye2{}.Textures)+unsafe.Offsetof(FishEyeEyeInformation{}.Distortion)-C.offsetof_ovrLayerFishEye2_Textures_Distortion]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(SwapChainCreateInfo{})-C.sizeof_ovrSwapChainCreateInfo]
+	_ = [1]struct{}{}[unsafe.Offsetof(SwapChainCreateInfo{}.Width)-C.offsetof_ovrSwapChainCreateInfo_Width]
+	_ = [1]struct{}{}[unsafe.Offsetof(SwapChainCreateInfo{}.BufferCount)-C.offsetof_ovrSwapChainCreateInfo_BufferCount]
+	_ = [1]struct{}{}[unsafe.Offsetof(SwapChainCreateInfo{}.CreateFlags)-C.offsetof_ovrSwapChainCreateInfo_CreateFlags]
+	_ = [1]struct{}{}[unsafe.Offsetof(SwapChainCreateInfo{}.UsageFlags)-C.offsetof_ovrSwapChainCreateInfo_UsageFlags]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputCapabilityHeader{})-C.sizeof_ovrInputCapabilityHeader]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputCapabilityHeader{}.DeviceID)-C.offsetof_ovrInputCapabilityHeader_DeviceID]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputStateHeader{})-C.sizeof_ovrInputStateHeader]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateHeader{}.TimeInSeconds)-C.offsetof_ovrInputStateHeader_TimeInSeconds]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputStateTrackedRemote{})-C.sizeof_ovrInputStateTrackedRemote]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.Buttons)-C.offsetof_ovrInputStateTrackedRemote_Buttons]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.TrackpadStatus)-C.offsetof_ovrInputStateTrackedRemote_TrackpadStatus]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.TrackpadPosition)-C.offsetof_ovrInputStateTrackedRemote_TrackpadPosition]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.BatteryPercentRemaining)-C.offsetof_ovrInputStateTrackedRemote_BatteryPercentRemaining]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.RecenterCount)-C.offsetof_ovrInputStateTrackedRemote_RecenterCount]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.IndexTrigger)-C.offsetof_ovrInputStateTrackedRemote_IndexTrigger]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.GripTrigger)-C.offsetof_ovrInputStateTrackedRemote_GripTrigger]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.Touches)-C.offsetof_ovrInputStateTrackedRemote_Touches]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.Joystick)-C.offsetof_ovrInputStateTrackedRemote_Joystick]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateTrackedRemote{}.JoystickNoDeadZone)-C.offsetof_ovrInputStateTrackedRemote_JoystickNoDeadZone]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputStateStandardPointer{})-C.sizeof_ovrInputStateStandardPointer]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateStandardPointer{}.PointerPose)-C.offsetof_ovrInputStateStandardPointer_PointerPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateStandardPointer{}.PointerStrength)-C.offsetof_ovrInputStateStandardPointer_PointerStrength]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateStandardPointer{}.GripPose)-C.offsetof_ovrInputStateStandardPointer_GripPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateStandardPointer{}.InputStateStatus)-C.offsetof_ovrInputStateStandardPointer_InputStateStatus]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateStandardPointer{}.Reserved)-C.offsetof_ovrInputStateStandardPointer_Reserved]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputStandardPointerCapabilities{})-C.sizeof_ovrInputStandardPointerCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStandardPointerCapabilities{}.ControllerCapabilities)-C.offsetof_ovrInputStandardPointerCapabilities_ControllerCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStandardPointerCapabilities{}.HapticSamplesMax)-C.offsetof_ovrInputStandardPointerCapabilities_HapticSamplesMax]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStandardPointerCapabilities{}.HapticSampleDurationMS)-C.offsetof_ovrInputStandardPointerCapabilities_HapticSampleDurationMS]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStandardPointerCapabilities{}.Reserved)-C.offsetof_ovrInputStandardPointerCapabilities_Reserved]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRSystemCreateInfoVulkan{})-C.sizeof_ovrSystemCreateInfoVulkan]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRSystemCreateInfoVulkan{}.PhysicalDevice)-C.offsetof_ovrSystemCreateInfoVulkan_PhysicalDevice]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRSystemCreateInfoVulkan{}.Device)-C.offsetof_ovrSystemCreateInfoVulkan_Device]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRModeParmsVulkan{})-C.sizeof_ovrModeParmsVulkan]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRModeParmsVulkan{}.SynchronizationQueue)-C.offsetof_ovrModeParmsVulkan_SynchronizationQueue]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRBoundaryTriggerResult{})-C.sizeof_ovrBoundaryTriggerResult]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRBoundaryTriggerResult{}.ClosestPointNormal)-C.offsetof_ovrBoundaryTriggerResult_ClosestPointNormal]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRBoundaryTriggerResult{}.ClosestDistance)-C.offsetof_ovrBoundaryTriggerResult_ClosestDistance]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRBoundaryTriggerResult{}.IsTriggering)-C.offsetof_ovrBoundaryTriggerResult_IsTriggering]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputTrackedRemoteCapabilities{})-C.sizeof_ovrInputTrackedRemoteCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.ControllerCapabilities)-C.offsetof_ovrInputTrackedRemoteCapabilities_ControllerCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.ButtonCapabilities)-C.offsetof_ovrInputTrackedRemoteCapabilities_ButtonCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.TrackpadMaxX)-C.offsetof_ovrInputTrackedRemoteCapabilities_TrackpadMaxX]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.TrackpadMaxY)-C.offsetof_ovrInputTrackedRemoteCapabilities_TrackpadMaxY]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.TrackpadSizeX)-C.offsetof_ovrInputTrackedRemoteCapabilities_TrackpadSizeX]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.TrackpadSizeY)-C.offsetof_ovrInputTrackedRemoteCapabilities_TrackpadSizeY]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.HapticSamplesMax)-C.offsetof_ovrInputTrackedRemoteCapabilities_HapticSamplesMax]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.HapticSampleDurationMS)-C.offsetof_ovrInputTrackedRemoteCapabilities_HapticSampleDurationMS]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.TouchCapabilities)-C.offsetof_ovrInputTrackedRemoteCapabilities_TouchCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.Reserved4)-C.offsetof_ovrInputTrackedRemoteCapabilities_Reserved4]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputTrackedRemoteCapabilities{}.Reserved5)-C.offsetof_ovrInputTrackedRemoteCapabilities_Reserved5]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRTracking{})-C.sizeof_ovrTracking]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRTracking{}.HeadPose)-C.offsetof_ovrTracking_HeadPose]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputHandCapabilities{})-C.sizeof_ovrInputHandCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputHandCapabilities{}.HandCapabilities)-C.offsetof_ovrInputHandCapabilities_HandCapabilities]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputHandCapabilities{}.StateCapabilities)-C.offsetof_ovrInputHandCapabilities_StateCapabilities]
+
+	_ = [1]struct{}{}[unsafe.Sizeof(OVRInputStateHand{})-C.sizeof_ovrInputStateHand]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateHand{}.PinchStrength)-C.offsetof_ovrInputStateHand_PinchStrength]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateHand{}.PointerPose)-C.offsetof_ovrInputStateHand_PointerPose]
+	_ = [1]struct{}{}[unsafe.Offsetof(OVRInputStateHand{}.InputStateStatus)-C.offsetof_ovrInputStateHand_InputStateStatus]
+)
@@ -0,0 +1,11 @@
+package vrapi
+
+// On 32 bit ARM C aligns 8 byte fields (double, uint64_t) to 8 bytes while Go
+// only aligns them to 4, so any padding C inserts in front of them (along with
+// the headers' OVR_VRAPI_PADDING_32_BIT) has to be spelled out in Go.
+type padding32Bit [4]byte
+
+type cubeTextures struct {
+	Textures [FRAME_LAYER_EYE_MAX]CubeEyeInformation
+	_        [4]byte // C pads ovrLayerCube2 to a multiple of 8 bytes.
+}
@@ -0,0 +1,11 @@
+//go:build !arm
+
+package vrapi
+
+// On 64 bit ARM (and other 64 bit platforms) Go and C agree on the alignment of 8 byte fields so
+// no extra padding is needed. See layout_arm.go.
+type padding32Bit [0]byte
+
+type cubeTextures struct {
+	Textures [FRAME_LAYER_EYE_MAX]CubeEyeInformation
+}
@@ -0,0 +1,920 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Go mirrors of C structs.
+var layoutTypes = map[string]string{
+	"ovrJava":                   "OVRJava",
+	"ovrPosef":                  "OVRPosef",
+	"ovrRectf":                  "OVRRectf",
+	"ovrModeParms":              "OVRModeParms",
+	"ovrModeParmsVulkan":        "OVRModeParmsVulkan",
+	"ovrRigidBodyPosef":         "OVRRigidBodyPosef",
+	"ovrTracking2":              "OVRTracking2",
+	"ovrTracking":               "OVRTracking",
+	"ovrBoundaryTriggerResult":  "OVRBoundaryTriggerResult",
+	"ovrSwapChainCreateInfo":    "SwapChainCreateInfo",
+	"ovrLayerHeader2":           "OVRLayerHeader2",
+	"ovrLayerProjection2":       "OVRLayerProjection2",
+	"ovrLayerCylinder2":         "OVRLayerCylinder2",
+	"ovrLayerCube2":             "OVRLayerCube2",
+	"ovrLayerEquirect2":         "OVRLayerEquirect2",
+	"ovrLayerEquirect3":         "OVRLayerEquirect3",
+	"ovrLayerLoadingIcon2":      "OVRLayerLoadingIcon2",
+	"ovrLayerFishEye2":          "OVRLayerFishEye2",
+	"ovrSystemCreateInfoVulkan": "OVRSystemCreateInfoVulkan",
+
+	"ovrInputCapabilityHeader":            "OVRInputCapabilityHeader",
+	"ovrInputStateHeader":                 "OVRInputStateHeader",
+	"ovrInputTrackedRemoteCapabilities":   "OVRInputTrackedRemoteCapabilities",
+	"ovrInputStandardPointerCapabilities": "OVRInputStandardPointerCapabilities",
+	"ovrInputHandCapabilities":            "OVRInputHandCapabilities",
+	"ovrInputStateTrackedRemote":          "OVRInputStateTrackedRemote",
+	"ovrInputStateStandardPointer":        "OVRInputStateStandardPointer",
+	"ovrInputStateHand":                   "OVRInputStateHand",
+}
+
+var assertTypeSize = regexp.MustCompile(
+	`(?m)^OVR_VRAPI_ASSERT_TYPE_SIZE(_32_BIT|_64_BIT)?\((\w+), (\d+)\);`)
+
+// headerSizes returns the sizes the headers assert for each C type on a 32
+// or 64 bit compiler.
+func headerSizes(t *testing.T, bits int) map[string]int64 {
+	t.Helper()
+	paths, err := filepath.Glob("Include/*.h")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no headers found: %v", err)
+	}
+
+	sizes := make(map[string]int64)
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range assertTypeSize.FindAllStringSubmatch(string(src), -1) {
+			if m[1] != "" && m[1] != "_"+strconv.Itoa(bits)+"_BIT" {
+				continue
+			}
+			size, _ := strconv.ParseInt(m[3], 10, 64)
+			sizes[m[2]] = size
+		}
+	}
+	return sizes
+}
+
+// fakeC stands in for cgo's "C" package with just what the mirrored structs
+// need from it.
+func fakeC() *types.Package {
+	pkg := types.NewPackage("C", "C")
+	scope := pkg.Scope()
+
+	pointer := types.Typ[types.UnsafePointer]
+	java := types.NewStruct([]*types.Var{
+		types.NewField(token.NoPos, pkg, "Vm", pointer, false),
+		types.NewField(token.NoPos, pkg, "Env", pointer, false),
+		types.NewField(token.NoPos, pkg, "ActivityObject", pointer, false),
+	}, nil)
+	scope.Insert(types.NewTypeName(token.NoPos, pkg, "ovrJava", java))
+
+	for name, value := range map[string]int64{
+		"VRAPI_FRAME_LAYER_EYE_MAX": 2,
+		"ovrMaxLayerCount":          16,
+	} {
+		scope.Insert(types.NewConst(token.NoPos, pkg, name, types.Typ[types.UntypedInt],
+			constant.MakeInt64(value)))
+	}
+
+	pkg.MarkComplete()
+	return pkg
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// checkPackage type checks the package as it is built for android on goarch.
+// Type errors are ignored since fakeC only declares a fraction of "C".
+func checkPackage(t *testing.T, goarch string) (*types.Package, types.Sizes) {
+	t.Helper()
+	ctxt := build.Default
+	ctxt.GOOS = "android"
+	ctxt.GOARCH = goarch
+	ctxt.CgoEnabled = true
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := ctxt.MatchFile(".", name); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+
+	c := fakeC()
+	source := importer.ForCompiler(fset, "source", nil)
+	sizes := types.SizesFor("gc", goarch)
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "C" {
+				return c, nil
+			}
+			return source.Import(path)
+		}),
+		Sizes: sizes,
+		Error: func(error) {},
+	}
+	pkg, _ := conf.Check("vrapi", fset, files, nil)
+	return pkg, sizes
+}
+
+// layout.go checks the layout against the C compiler the package is built
+// with, which on a host only covers 64 bit. This lays out the C structs for
+// both ARM ABIs, checks them against the sizes asserted by the headers and
+// compares the size and the offset of every field with the Go mirrors.
+func TestLayout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks the package from source")
+	}
+
+	for _, arch := range []struct {
+		goarch string
+		bits   int
+	}{
+		{"arm", 32},
+		{"arm64", 64},
+	} {
+		t.Run(arch.goarch, func(t *testing.T) {
+			pkg, sizes := checkPackage(t, arch.goarch)
+			want := headerSizes(t, arch.bits)
+
+			l := newCLayout(arch.bits)
+			for _, header := range []string{"VrApi_Types.h", "VrApi_Input.h", "VrApi_Vulkan.h"} {
+				if err := l.parse(filepath.Join("Include", header)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for cName, size := range want {
+				if c, ok := l.types[cName]; ok && c.size != size {
+					t.Errorf("%s laid out as %d bytes, the headers assert %d", cName, c.size, size)
+				}
+			}
+
+			for cName, goName := range layoutTypes {
+				c, ok := l.types[cName]
+				if !ok {
+					t.Errorf("%s not declared by the headers", cName)
+					continue
+				}
+				obj := pkg.Scope().Lookup(goName)
+				if obj == nil {
+					t.Errorf("%s not declared", goName)
+					continue
+				}
+				checkLayout(t, pkg, sizes, goName, obj.Type(), c)
+			}
+		})
+	}
+}
+
+// cType is a C type as laid out by one ABI.
+type cType struct {
+	size   int64
+	align  int64
+	fields []cField // Structs and unions.
+	elem   *cType   // Arrays.
+	len    int64
+}
+
+// cField is a struct or union member. Padding and anonymous unions have no
+// name.
+type cField struct {
+	name   string
+	offset int64
+	typ    *cType
+}
+
+func alignUp(n, align int64) int64 {
+	return (n + align - 1) / align * align
+}
+
+func cScalar(size int64) *cType {
+	return &cType{size: size, align: size}
+}
+
+func cArray(elem *cType, n int64) *cType {
+	return &cType{size: elem.size * n, align: elem.align, elem: elem, len: n}
+}
+
+// cLayout lays out the C structs of the headers for an android ARM ABI,
+// 32 bit ARM EABI or 64 bit AAPCS64. Both align 8 byte types to 8. It only
+// understands as much C as the headers use.
+type cLayout struct {
+	bits    int
+	types   map[string]*cType // Typedefs and "struct tag"s.
+	consts  map[string]int64  // Enum constants.
+	defined map[string]bool   // Macros the preprocessor treats as defined.
+
+	toks []string
+	pos  int
+	err  error
+}
+
+func newCLayout(bits int) *cLayout {
+	l := &cLayout{
+		bits:    bits,
+		types:   make(map[string]*cType),
+		consts:  make(map[string]int64),
+		defined: map[string]bool{"OVR_VRAPI_64_BIT": bits == 64},
+	}
+	if bits == 64 {
+		l.defined["__aarch64__"] = true
+		l.defined["__LP64__"] = true
+	}
+	for name, size := range map[string]int64{
+		"int8_t": 1, "uint8_t": 1, "int16_t": 2, "uint16_t": 2,
+		"int32_t": 4, "uint32_t": 4, "int64_t": 8, "uint64_t": 8,
+		"size_t": int64(bits / 8), "intptr_t": int64(bits / 8), "uintptr_t": int64(bits / 8),
+	} {
+		l.types[name] = cScalar(size)
+	}
+	return l
+}
+
+var (
+	cComment = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	cToken   = regexp.MustCompile(`[A-Za-z_]\w*|\d\w*|<<|>>|\|\||&&|\S`)
+)
+
+// parse adds the declarations in the header at path.
+func (l *cLayout) parse(path string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	text := cComment.ReplaceAllString(string(src), "")
+	text = strings.ReplaceAll(text, "\\\n", " ")
+	text, err = l.preprocess(text)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	l.toks, l.pos = cToken.FindAllString(text, -1), 0
+	for l.err == nil && l.pos < len(l.toks) {
+		l.declaration()
+	}
+	if l.err != nil {
+		return fmt.Errorf("%s: %v", path, l.err)
+	}
+	return nil
+}
+
+// preprocess drops the lines #if and friends leave out along with every
+// directive. Macros are not expanded.
+func (l *cLayout) preprocess(text string) (string, error) {
+	type cond struct{ active, taken bool }
+	var stack []cond
+	active := func() bool {
+		for _, c := range stack {
+			if !c.active {
+				return false
+			}
+		}
+		return true
+	}
+
+	var out strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "#") {
+			if active() {
+				out.WriteString(line)
+			}
+			out.WriteByte('\n')
+			continue
+		}
+
+		directive := strings.Fields(strings.TrimSpace(trimmed[1:]) + " ")
+		var name, arg string
+		if len(directive) > 0 {
+			name = directive[0]
+			arg = strings.Join(directive[1:], " ")
+		}
+		switch name {
+		case "if", "ifdef", "ifndef":
+			var ok bool
+			var err error
+			switch name {
+			case "if":
+				ok, err = l.condition(arg)
+			case "ifdef":
+				ok = l.defined[arg]
+			case "ifndef":
+				ok = !l.defined[arg]
+			}
+			if err != nil {
+				return "", err
+			}
+			stack = append(stack, cond{active: ok, taken: ok})
+		case "elif", "else", "endif":
+			if len(stack) == 0 {
+				return "", fmt.Errorf("#%s without #if", name)
+			}
+			top := &stack[len(stack)-1]
+			switch name {
+			case "elif":
+				ok, err := l.condition(arg)
+				if err != nil {
+					return "", err
+				}
+				top.active = ok && !top.taken
+				top.taken = top.taken || ok
+			case "else":
+				top.active = !top.taken
+				top.taken = true
+			case "endif":
+				stack = stack[:len(stack)-1]
+			}
+		}
+		out.WriteByte('\n')
+	}
+	return out.String(), nil
+}
+
+// condition evaluates an #if expression made of defined, !, && and ||.
+func (l *cLayout) condition(expr string) (bool, error) {
+	toks := cToken.FindAllString(expr, -1)
+	pos := 0
+	peek := func() string {
+		if pos < len(toks) {
+			return toks[pos]
+		}
+		return ""
+	}
+	var or, unary func() (bool, error)
+	unary = func() (bool, error) {
+		switch tok := peek(); tok {
+		case "!":
+			pos++
+			v, err := unary()
+			return !v, err
+		case "(":
+			pos++
+			v, err := or()
+			if peek() != ")" {
+				return false, fmt.Errorf("#if %s: missing )", expr)
+			}
+			pos++
+			return v, err
+		case "defined":
+			pos++
+			paren := peek() == "("
+			if paren {
+				pos++
+			}
+			v := l.defined[peek()]
+			pos++
+			if paren {
+				if peek() != ")" {
+					return false, fmt.Errorf("#if %s: missing )", expr)
+				}
+				pos++
+			}
+			return v, nil
+		default:
+			return false, fmt.Errorf("#if %s: can not evaluate %q", expr, tok)
+		}
+	}
+	and := func() (bool, error) {
+		v, err := unary()
+		for err == nil && peek() == "&&" {
+			pos++
+			var w bool
+			w, err = unary()
+			v = v && w
+		}
+		return v, err
+	}
+	or = func() (bool, error) {
+		v, err := and()
+		for err == nil && peek() == "||" {
+			pos++
+			var w bool
+			w, err = and()
+			v = v || w
+		}
+		return v, err
+	}
+
+	v, err := or()
+	if err == nil && pos != len(toks) {
+		err = fmt.Errorf("#if %s: unexpected %q", expr, peek())
+	}
+	return v, err
+}
+
+func (l *cLayout) peek() string {
+	if l.pos < len(l.toks) {
+		return l.toks[l.pos]
+	}
+	return ""
+}
+
+func (l *cLayout) next() string {
+	tok := l.peek()
+	l.pos++
+	return tok
+}
+
+func (l *cLayout) expect(tok string) {
+	if got := l.next(); got != tok && l.err == nil {
+		l.err = fmt.Errorf("found %q, want %q", got, tok)
+	}
+}
+
+func (l *cLayout) fail(format string, args ...interface{}) {
+	if l.err == nil {
+		l.err = fmt.Errorf(format, args...)
+	}
+	l.pos = len(l.toks)
+}
+
+// skip moves past the next ; outside of any brackets.
+func (l *cLayout) skip() {
+	depth := 0
+	for l.pos < len(l.toks) {
+		switch l.next() {
+		case "(", "{", "[":
+			depth++
+		case ")", "}", "]":
+			depth--
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+func (l *cLayout) declaration() {
+	switch tok := l.peek(); tok {
+	case "typedef":
+		l.next()
+		base := l.typeSpec()
+		if l.peek() == "(" { // A function pointer.
+			l.skip()
+			return
+		}
+		for l.err == nil {
+			name, typ := l.declarator(base)
+			l.types[name] = typ
+			if l.peek() != "," {
+				break
+			}
+			l.next()
+		}
+		l.expect(";")
+	case "struct", "union", "enum":
+		l.typeSpec()
+		l.skip()
+	case "VK_DEFINE_HANDLE", "VK_DEFINE_NON_DISPATCHABLE_HANDLE":
+		// No ; follows these.
+		l.next()
+		l.expect("(")
+		name := l.next()
+		l.expect(")")
+		if tok == "VK_DEFINE_HANDLE" || l.bits == 64 {
+			l.types[name] = cScalar(int64(l.bits / 8))
+		} else {
+			l.types[name] = cScalar(8)
+		}
+	default:
+		// Functions and static asserts.
+		l.skip()
+	}
+}
+
+var cBuiltin = map[string]bool{
+	"void": true, "char": true, "short": true, "int": true, "long": true,
+	"float": true, "double": true, "signed": true, "unsigned": true, "bool": true,
+	"_Bool": true,
+}
+
+func (l *cLayout) typeSpec() *cType {
+	for l.peek() == "const" || l.peek() == "volatile" {
+		l.next()
+	}
+
+	switch kw := l.peek(); kw {
+	case "struct", "union":
+		l.next()
+		tag := ""
+		if l.peek() != "{" {
+			tag = kw + " " + l.next()
+		}
+		if l.peek() != "{" {
+			if t, ok := l.types[tag]; ok {
+				return t
+			}
+			return &cType{} // Opaque, only ever pointed to.
+		}
+		t := l.body(kw == "union")
+		if tag != "" {
+			l.types[tag] = t
+		}
+		return t
+	case "enum":
+		l.next()
+		if l.peek() != "{" {
+			l.next()
+		}
+		if l.peek() == "{" {
+			l.enumBody()
+		}
+		return cScalar(4)
+	}
+
+	var words []string
+	for cBuiltin[l.peek()] {
+		words = append(words, l.next())
+	}
+	if len(words) == 0 {
+		name := l.next()
+		t, ok := l.types[name]
+		if !ok {
+			l.fail("unknown type %q", name)
+			return cScalar(1)
+		}
+		return t
+	}
+
+	longs := 0
+	for _, word := range words {
+		switch word {
+		case "void":
+			return &cType{}
+		case "char", "bool", "_Bool":
+			return cScalar(1)
+		case "short":
+			return cScalar(2)
+		case "float":
+			return cScalar(4)
+		case "double":
+			return cScalar(8)
+		case "long":
+			longs++
+		}
+	}
+	switch longs {
+	case 0:
+		return cScalar(4)
+	case 1:
+		return cScalar(int64(l.bits / 8))
+	default:
+		return cScalar(8)
+	}
+}
+
+// declarator reads pointers, a name and array dimensions applied to base.
+func (l *cLayout) declarator(base *cType) (string, *cType) {
+	t := base
+	for l.peek() == "*" || l.peek() == "const" {
+		if l.next() == "*" {
+			t = cScalar(int64(l.bits / 8))
+		}
+	}
+	name := l.next()
+
+	var dims []int64
+	for l.err == nil && l.peek() == "[" {
+		l.next()
+		start := l.pos
+		for l.pos < len(l.toks) && l.peek() != "]" {
+			l.next()
+		}
+		n, ok := l.eval(l.toks[start:l.pos])
+		if !ok {
+			l.fail("array size of %s: can not evaluate %q", name, l.toks[start:l.pos])
+		}
+		dims = append(dims, n)
+		l.expect("]")
+	}
+	// T x[2][3] is an array of 2 arrays of 3 T.
+	for i := len(dims) - 1; i >= 0; i-- {
+		t = cArray(t, dims[i])
+	}
+	return name, t
+}
+
+func (l *cLayout) enumBody() {
+	l.expect("{")
+	value, known := int64(0), true
+	for l.err == nil && l.peek() != "}" {
+		name := l.next()
+		if l.peek() == "=" {
+			l.next()
+			start, depth := l.pos, 0
+			for l.pos < len(l.toks) {
+				tok := l.peek()
+				if depth == 0 && (tok == "," || tok == "}") {
+					break
+				}
+				if tok == "(" {
+					depth++
+				} else if tok == ")" {
+					depth--
+				}
+				l.next()
+			}
+			value, known = l.eval(l.toks[start:l.pos])
+		}
+		if known {
+			l.consts[name] = value
+		}
+		value++
+		if l.peek() == "," {
+			l.next()
+		}
+	}
+	l.expect("}")
+}
+
+// eval evaluates an integer constant expression, reporting false for
+// anything it does not understand.
+func (l *cLayout) eval(toks []string) (int64, bool) {
+	pos := 0
+	peek := func() string {
+		if pos < len(toks) {
+			return toks[pos]
+		}
+		return ""
+	}
+	precedence := map[string]int{
+		"|": 1, "&": 2, "<<": 3, ">>": 3, "+": 4, "-": 4, "*": 5, "/": 5,
+	}
+
+	var binary func(min int) (int64, bool)
+	unary := func() (int64, bool) {
+		tok := peek()
+		pos++
+		switch {
+		case tok == "-":
+			v, ok := binary(6)
+			return -v, ok
+		case tok == "(":
+			v, ok := binary(1)
+			if peek() != ")" {
+				return 0, false
+			}
+			pos++
+			return v, ok
+		case tok != "" && tok[0] >= '0' && tok[0] <= '9':
+			v, err := strconv.ParseInt(strings.TrimRight(tok, "uUlL"), 0, 64)
+			return v, err == nil
+		default:
+			v, ok := l.consts[tok]
+			return v, ok
+		}
+	}
+	binary = func(min int) (int64, bool) {
+		v, ok := unary()
+		for ok {
+			op := peek()
+			prec, isOp := precedence[op]
+			if !isOp || prec < min {
+				break
+			}
+			pos++
+			var w int64
+			w, ok = binary(prec + 1)
+			switch op {
+			case "|":
+				v |= w
+			case "&":
+				v &= w
+			case "<<":
+				v <<= uint(w)
+			case ">>":
+				v >>= uint(w)
+			case "+":
+				v += w
+			case "-":
+				v -= w
+			case "*":
+				v *= w
+			case "/":
+				if w == 0 {
+					return 0, false
+				}
+				v /= w
+			}
+		}
+		return v, ok
+	}
+
+	v, ok := binary(1)
+	return v, ok && pos == len(toks)
+}
+
+// body lays out the members of a struct or union.
+func (l *cLayout) body(union bool) *cType {
+	l.expect("{")
+	t := &cType{align: 1}
+	var offset int64
+	add := func(name string, ft *cType) {
+		if ft.size == 0 {
+			l.fail("member %s has an incomplete type", name)
+			return
+		}
+		if union {
+			offset = 0
+		}
+		offset = alignUp(offset, ft.align)
+		t.fields = append(t.fields, cField{name, offset, ft})
+		offset += ft.size
+		if offset > t.size {
+			t.size = offset
+		}
+		if ft.align > t.align {
+			t.align = ft.align
+		}
+	}
+
+	for l.err == nil && l.peek() != "}" {
+		if tok := l.peek(); strings.HasPrefix(tok, "OVR_VRAPI_PADDING") {
+			// No ; follows these.
+			l.next()
+			l.expect("(")
+			n, err := strconv.ParseInt(l.next(), 10, 64)
+			if err != nil {
+				l.fail("%s: %v", tok, err)
+			}
+			l.expect(")")
+			if tok == "OVR_VRAPI_PADDING" || tok == fmt.Sprintf("OVR_VRAPI_PADDING_%d_BIT", l.bits) {
+				add("", cArray(cScalar(1), n))
+			}
+			continue
+		}
+
+		base := l.typeSpec()
+		if l.peek() == ";" { // An anonymous struct or union.
+			l.next()
+			add("", base)
+			continue
+		}
+		for l.err == nil {
+			add(l.declarator(base))
+			if l.peek() != "," {
+				break
+			}
+			l.next()
+		}
+		l.expect(";")
+	}
+	l.expect("}")
+	t.size = alignUp(t.size, t.align)
+	return t
+}
+
+// goField is a field of a Go struct with embedded structs flattened. Blank
+// fields and ones named Padding stand in for the headers' padding macros.
+type goField struct {
+	offset int64
+	typ    types.Type
+}
+
+func goFields(sizes types.Sizes, s *types.Struct, base int64, fields map[string]goField) {
+	vars := make([]*types.Var, s.NumFields())
+	for i := range vars {
+		vars[i] = s.Field(i)
+	}
+	offsets := sizes.Offsetsof(vars)
+	for i, v := range vars {
+		if v.Name() == "_" || v.Name() == "Padding" {
+			continue
+		}
+		if st, ok := v.Type().Underlying().(*types.Struct); ok && v.Embedded() {
+			goFields(sizes, st, base+offsets[i], fields)
+			continue
+		}
+		fields[strings.ToLower(v.Name())] = goField{base + offsets[i], v.Type()}
+	}
+}
+
+// cFields flattens anonymous structs and unions into fields, leaving out
+// padding. Every member of an anonymous union gets the same group so only
+// one of them needs a Go counterpart.
+func cFields(c *cType, base int64, group *int, fields *[]cField, groups *[]int) {
+	union := len(c.fields) > 1
+	for _, f := range c.fields {
+		union = union && f.offset == 0
+	}
+	g := 0
+	if union {
+		*group++
+		g = *group
+	}
+	for _, f := range c.fields {
+		switch {
+		case f.name != "":
+			*fields = append(*fields, cField{f.name, base + f.offset, f.typ})
+			*groups = append(*groups, g)
+		case f.typ.fields != nil:
+			cFields(f.typ, base+f.offset, group, fields, groups)
+		}
+	}
+}
+
+// checkLayout compares the size of the Go type with the C type it mirrors
+// and, for structs declared in pkg and arrays of them, the offset of every
+// field.
+func checkLayout(t *testing.T, pkg *types.Package, sizes types.Sizes, name string,
+	goType types.Type, c *cType) {
+
+	t.Helper()
+	if got := sizes.Sizeof(goType); got != c.size {
+		t.Errorf("%s is %d bytes in Go, %d in C", name, got, c.size)
+	}
+
+	switch g := goType.(type) {
+	case *types.Array:
+		if c.elem == nil || g.Len() != c.len {
+			t.Errorf("%s is %v in Go, not an array of %d in C", name, g, c.len)
+			return
+		}
+		checkLayout(t, pkg, sizes, name+"[0]", g.Elem(), c.elem)
+		return
+	case *types.Named:
+		if g.Obj().Pkg() != pkg || c.fields == nil {
+			return
+		}
+	}
+	s, ok := goType.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	fields := make(map[string]goField)
+	goFields(sizes, s, 0, fields)
+
+	var group int
+	var cfs []cField
+	var groups []int
+	cFields(c, 0, &group, &cfs, &groups)
+
+	matched := make(map[int]bool)
+	for i, f := range cfs {
+		g, ok := fields[strings.ToLower(f.name)]
+		if !ok {
+			if groups[i] == 0 {
+				t.Errorf("%s.%s has no Go field", name, f.name)
+			}
+			continue
+		}
+		delete(fields, strings.ToLower(f.name))
+		matched[groups[i]] = true
+		if g.offset != f.offset {
+			t.Errorf("%s.%s is at %d in Go, %d in C", name, f.name, g.offset, f.offset)
+			continue
+		}
+		checkLayout(t, pkg, sizes, name+"."+f.name, g.typ, f.typ)
+	}
+	for i, g := range groups {
+		if g != 0 && !matched[g] {
+			t.Errorf("%s.%s has no Go field", name, cfs[i].name)
+			matched[g] = true
+		}
+	}
+	for goName := range fields {
+		t.Errorf("%s.%s has no C field", name, goName)
+	}
+}
@@ -2,7 +2,7 @@ package ovrMatrix4f
 
 /*
 #cgo CPPFLAGS: -I../Include -I../usr/local/include
-#cgo LDFLAGS: -v -march=armv8-a -shared -L../lib/arm64-v8a/ -lvrapi -landroid
+#cgo android LDFLAGS: -v -march=armv8-a -shared -L../lib/arm64-v8a/ -lvrapi -landroid
 
 #include <VrApi_Helpers.h>
 */
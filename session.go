@@ -0,0 +1,161 @@
+package vrapi
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrSessionNotInVrMode is returned when submitting frames to a Session
+// that is not currently in vr mode.
+var ErrSessionNotInVrMode = errors.New("session is not in vr mode")
+
+type SessionState int
+
+const (
+	SessionPaused   SessionState = iota // The activity is not resumed.
+	SessionResumed                      // Resumed but waiting on a native window.
+	SessionInVrMode                     // Resumed with a native window and in vr mode.
+)
+
+func (s SessionState) String() string {
+	switch s {
+	case SessionPaused:
+		return "Paused"
+	case SessionResumed:
+		return "Resumed"
+	case SessionInVrMode:
+		return "InVrMode"
+	}
+	return "Unknown"
+}
+
+// SessionRuntime is the part of the Context a Session drives. *Context is
+// the real one, a fake can stand in for it to test the life cycle.
+type SessionRuntime interface {
+	EnterVrMode(modeParms *OVRModeParms) *OVRMobile
+	LeaveVrMode(vrApp *OVRMobile) error
+	SubmitFrame2(vrApp *OVRMobile, frameDesc *OVRSubmitFrameDescription2) error
+}
+
+// Session owns the OVRMobile and enters / leaves vr mode following the
+// Android life cycle described in VrApi.h. An activity can only be in vr mode
+// while it is resumed and has a valid native window, so feed Resume, Pause,
+// WindowCreated and WindowDestroyed from the matching activity callbacks
+// and the session takes care of the rest.
+type Session struct {
+	mu sync.Mutex
+
+	runtime   SessionRuntime
+	modeParms OVRModeParms
+
+	resumed bool
+	window  uintptr
+	ovr     *OVRMobile
+}
+
+// NewSession expects modeParms to have Display and ShareContext set.
+// WindowSurface is filled in from WindowCreated.
+func NewSession(runtime SessionRuntime, modeParms OVRModeParms) *Session {
+	return &Session{runtime: runtime, modeParms: modeParms}
+}
+
+func (s *Session) State() SessionState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state()
+}
+
+func (s *Session) state() SessionState {
+	switch {
+	case s.ovr != nil:
+		return SessionInVrMode
+	case s.resumed:
+		return SessionResumed
+	}
+	return SessionPaused
+}
+
+// Mobile returns the OVRMobile while in vr mode otherwise nil.
+func (s *Session) Mobile() *OVRMobile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ovr
+}
+
+func (s *Session) Resume() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resumed = true
+	return s.update()
+}
+
+func (s *Session) Pause() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resumed = false
+	return s.update()
+}
+
+// WindowCreated takes the ANativeWindow for the activity's surface. Calling
+// it again with a different window (the surface changed) leaves and
+// re-enters vr mode with the new one.
+func (s *Session) WindowCreated(window uintptr) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ovr != nil && window != s.window {
+		if err := s.leave(); err != nil {
+			return err
+		}
+	}
+	s.window = window
+	return s.update()
+}
+
+func (s *Session) WindowDestroyed() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.window = 0
+	return s.update()
+}
+
+func (s *Session) SubmitFrame2(frameDesc *OVRSubmitFrameDescription2) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ovr == nil {
+		return ErrSessionNotInVrMode
+	}
+	return s.runtime.SubmitFrame2(s.ovr, frameDesc)
+}
+
+// update enters or leaves vr mode to match the life cycle state.
+func (s *Session) update() error {
+	shouldEnter := s.resumed && s.window != 0
+	switch {
+	case shouldEnter && s.ovr == nil:
+		parms := s.modeParms
+		parms.Flags |= MODE_FLAG_NATIVE_WINDOW
+		parms.WindowSurface = uint64(s.window)
+
+		ovr := s.runtime.EnterVrMode(&parms)
+		if ovr == nil {
+			return errors.New("vrapi_EnterVrMode failed")
+		}
+		s.ovr = ovr
+	case !shouldEnter && s.ovr != nil:
+		return s.leave()
+	}
+	return nil
+}
+
+func (s *Session) leave() error {
+	err := s.runtime.LeaveVrMode(s.ovr)
+	s.ovr = nil
+	return err
+}
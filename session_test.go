@@ -0,0 +1,212 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+// recordingRuntime logs what a Session asks of the runtime and passes it on
+// to a Context backed by the fake runtime, unless failEnter is set.
+type recordingRuntime struct {
+	c         *Context
+	failEnter bool
+	calls     []string
+}
+
+func (r *recordingRuntime) EnterVrMode(modeParms *OVRModeParms) *OVRMobile {
+	r.calls = append(r.calls, fmt.Sprintf("enter %d", modeParms.WindowSurface))
+	if modeParms.Flags&MODE_FLAG_NATIVE_WINDOW == 0 {
+		r.calls = append(r.calls, "missing MODE_FLAG_NATIVE_WINDOW")
+	}
+	if r.failEnter {
+		return nil
+	}
+	return r.c.EnterVrMode(modeParms)
+}
+
+func (r *recordingRuntime) LeaveVrMode(vrApp *OVRMobile) error {
+	r.calls = append(r.calls, "leave")
+	return r.c.LeaveVrMode(vrApp)
+}
+
+func (r *recordingRuntime) SubmitFrame2(vrApp *OVRMobile,
+	frameDesc *OVRSubmitFrameDescription2) error {
+
+	r.calls = append(r.calls, "submit")
+	return r.c.SubmitFrame2(vrApp, frameDesc)
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	resume := func(s *Session) error { return s.Resume() }
+	pause := func(s *Session) error { return s.Pause() }
+	window := func(w uintptr) func(s *Session) error {
+		return func(s *Session) error { return s.WindowCreated(w) }
+	}
+	destroyed := func(s *Session) error { return s.WindowDestroyed() }
+
+	tests := []struct {
+		name   string
+		events []func(s *Session) error
+		calls  []string
+		state  SessionState
+	}{
+		{
+			name:   "resume then window",
+			events: []func(s *Session) error{resume, window(1)},
+			calls:  []string{"enter 1"},
+			state:  SessionInVrMode,
+		},
+		{
+			name:   "window then resume",
+			events: []func(s *Session) error{window(1), resume},
+			calls:  []string{"enter 1"},
+			state:  SessionInVrMode,
+		},
+		{
+			name:   "window while paused",
+			events: []func(s *Session) error{window(1)},
+			state:  SessionPaused,
+		},
+		{
+			name:   "resume without window",
+			events: []func(s *Session) error{resume},
+			state:  SessionResumed,
+		},
+		{
+			name:   "pause leaves",
+			events: []func(s *Session) error{resume, window(1), pause},
+			calls:  []string{"enter 1", "leave"},
+			state:  SessionPaused,
+		},
+		{
+			name:   "window destroyed leaves",
+			events: []func(s *Session) error{resume, window(1), destroyed},
+			calls:  []string{"enter 1", "leave"},
+			state:  SessionResumed,
+		},
+		{
+			name:   "pause then window destroyed leaves once",
+			events: []func(s *Session) error{resume, window(1), pause, destroyed},
+			calls:  []string{"enter 1", "leave"},
+			state:  SessionPaused,
+		},
+		{
+			name:   "pause and resume enters again",
+			events: []func(s *Session) error{resume, window(1), pause, resume},
+			calls:  []string{"enter 1", "leave", "enter 1"},
+			state:  SessionInVrMode,
+		},
+		{
+			name:   "window changed re-enters",
+			events: []func(s *Session) error{resume, window(1), window(2)},
+			calls:  []string{"enter 1", "leave", "enter 2"},
+			state:  SessionInVrMode,
+		},
+		{
+			name:   "same window again",
+			events: []func(s *Session) error{resume, window(1), window(1)},
+			calls:  []string{"enter 1"},
+			state:  SessionInVrMode,
+		},
+		{
+			name:   "resume twice",
+			events: []func(s *Session) error{resume, window(1), resume},
+			calls:  []string{"enter 1"},
+			state:  SessionInVrMode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runtime := &recordingRuntime{c: newTestContext(t)}
+			s := NewSession(runtime, OVRModeParms{})
+
+			for i, event := range test.events {
+				if err := event(s); err != nil {
+					t.Fatalf("event %d: %v", i, err)
+				}
+			}
+
+			if !reflect.DeepEqual(runtime.calls, test.calls) {
+				t.Errorf("runtime calls %q, want %q", runtime.calls, test.calls)
+			}
+			if state := s.State(); state != test.state {
+				t.Errorf("state %v, want %v", state, test.state)
+			}
+			if inVrMode := s.Mobile() != nil; inVrMode != (test.state == SessionInVrMode) {
+				t.Errorf("Mobile() != nil is %v in state %v", inVrMode, test.state)
+			}
+
+			live := 0
+			if test.state == SessionInVrMode {
+				live = 1
+			}
+			if n := fakevrapi.GetState().LiveSessions; n != live {
+				t.Errorf("%d live sessions, want %d", n, live)
+			}
+
+			// Leave vr mode so the fake sees a clean shut down.
+			if err := s.Pause(); err != nil {
+				t.Errorf("Pause: %v", err)
+			}
+		})
+	}
+}
+
+func TestSessionSubmitFrame2(t *testing.T) {
+	runtime := &recordingRuntime{c: newTestContext(t)}
+	s := NewSession(runtime, OVRModeParms{})
+
+	frameDesc := OVRSubmitFrameDescription2{}
+	if err := s.SubmitFrame2(&frameDesc); !errors.Is(err, ErrSessionNotInVrMode) {
+		t.Errorf("SubmitFrame2 while paused returned %v, want ErrSessionNotInVrMode", err)
+	}
+
+	s.Resume()
+	s.WindowCreated(1)
+	if err := s.SubmitFrame2(&frameDesc); err != nil {
+		t.Errorf("SubmitFrame2 in vr mode: %v", err)
+	}
+
+	s.WindowDestroyed()
+	if err := s.SubmitFrame2(&frameDesc); !errors.Is(err, ErrSessionNotInVrMode) {
+		t.Errorf("SubmitFrame2 after the window was destroyed returned %v, want ErrSessionNotInVrMode", err)
+	}
+
+	want := []string{"enter 1", "submit", "leave"}
+	if !reflect.DeepEqual(runtime.calls, want) {
+		t.Errorf("runtime calls %q, want %q", runtime.calls, want)
+	}
+	if n := fakevrapi.GetState().Submits; n != 1 {
+		t.Errorf("%d frames reached the runtime, want 1", n)
+	}
+}
+
+func TestSessionEnterFails(t *testing.T) {
+	runtime := &recordingRuntime{c: newTestContext(t), failEnter: true}
+	s := NewSession(runtime, OVRModeParms{})
+
+	s.Resume()
+	if err := s.WindowCreated(1); err == nil {
+		t.Error("WindowCreated returned nil error when vrapi_EnterVrMode failed")
+	}
+	if state := s.State(); state != SessionResumed {
+		t.Errorf("state %v after failing to enter, want Resumed", state)
+	}
+
+	// Retried on the next life cycle event.
+	runtime.failEnter = false
+	if err := s.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if state := s.State(); state != SessionInVrMode {
+		t.Errorf("state %v, want InVrMode", state)
+	}
+	s.Pause()
+}
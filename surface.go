@@ -0,0 +1,95 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <VrApi.h>
+*/
+import "C"
+
+import (
+	"fmt"
+)
+
+// CreateAndroidSurfaceSwapChain creates a swap chain backed by an Android
+// SurfaceTexture, e.g. for a video decoder to render into. Pass the surface
+// from GetTextureSwapChainAndroidSurface to the producer (MediaPlayer,
+// MediaCodec, ...) and the compositor picks up new frames on its own.
+//
+// Width and height set the default buffer size, zero leaves it to the
+// producer, which may override it anyway.
+//
+// To show the video attach the swap chain to a layer like any other swap
+// chain. Surface swap chains only have one buffer so SwapChainIndex is
+// always 0. For a mono 360 video
+//
+//	layer := vrapi.DefaultLayerEquirect2()
+//	layer.HeadPose = tracking.HeadPose
+//	for eye := range layer.Textures {
+//		layer.Textures[eye].ColorSwapChain = swapChain
+//		layer.Textures[eye].SwapChainIndex = 0
+//	}
+//
+// For stereo video packed top / bottom (or side by side) adjust each eye's
+// TextureMatrix and TextureRect to select its half. Cylinder layers
+// (DefaultLayerCylinder2) work the same way for flat video.
+func (c *Context) CreateAndroidSurfaceSwapChain(width, height int) (OVRTextureSwapChain, error) {
+	var swapChain OVRTextureSwapChain
+	err := c.doErr(func() error {
+		cSwapChain := C.vrapi_CreateAndroidSurfaceSwapChain(C.int(width), C.int(height))
+		swapChain = newSwapChain(cSwapChain)
+		if swapChain == 0 {
+			return fmt.Errorf("vrapi_CreateAndroidSurfaceSwapChain failed for %dx%d",
+				width, height)
+		}
+		c.state.addSwapChain(swapChain, 0)
+		return nil
+	})
+
+	return swapChain, err
+}
+
+// CreateAndroidSurfaceSwapChain2 is CreateAndroidSurfaceSwapChain but with
+// isProtected the surface is created as a protected surface for secure
+// (DRM) video playback.
+func (c *Context) CreateAndroidSurfaceSwapChain2(width, height int,
+	isProtected bool) (OVRTextureSwapChain, error) {
+
+	var swapChain OVRTextureSwapChain
+	err := c.doErr(func() error {
+		cSwapChain := C.vrapi_CreateAndroidSurfaceSwapChain2(C.int(width), C.int(height),
+			C.bool(isProtected))
+		swapChain = newSwapChain(cSwapChain)
+		if swapChain == 0 {
+			return fmt.Errorf("vrapi_CreateAndroidSurfaceSwapChain2 failed for %dx%d",
+				width, height)
+		}
+		c.state.addSwapChain(swapChain, 0)
+		return nil
+	})
+
+	return swapChain, err
+}
+
+// GetTextureSwapChainAndroidSurface returns the android.view.Surface jobject
+// of a swap chain from CreateAndroidSurfaceSwapChain. Like the handles
+// CreateJavaObject takes it is a raw JNI handle, use it with JNI through
+// app.RunOnJVM from "golang.org/x/mobile/app". The runtime owns the surface
+// and it is only valid until the swap chain is destroyed.
+func (c *Context) GetTextureSwapChainAndroidSurface(swapChain OVRTextureSwapChain) (uintptr, error) {
+	var surface uintptr
+	err := c.doErr(func() error {
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+
+		cSwapChain := swapChain.c()
+		surface = uintptr(C.vrapi_GetTextureSwapChainAndroidSurface(cSwapChain))
+		if surface == 0 {
+			return fmt.Errorf("swap chain %#x has no android surface", swapChain)
+		}
+		return nil
+	})
+
+	return surface, err
+}
@@ -0,0 +1,90 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <VrApi.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+var trackingSpaceNames = map[OVRTrackingSpace]string{
+	TRACKING_SPACE_LOCAL:           "VRAPI_TRACKING_SPACE_LOCAL",
+	TRACKING_SPACE_LOCAL_FLOOR:     "VRAPI_TRACKING_SPACE_LOCAL_FLOOR",
+	TRACKING_SPACE_LOCAL_TILTED:    "VRAPI_TRACKING_SPACE_LOCAL_TILTED",
+	TRACKING_SPACE_STAGE:           "VRAPI_TRACKING_SPACE_STAGE",
+	TRACKING_SPACE_LOCAL_FIXED_YAW: "VRAPI_TRACKING_SPACE_LOCAL_FIXED_YAW",
+}
+
+func (s OVRTrackingSpace) String() string {
+	if name, ok := trackingSpaceNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("ovrTrackingSpace(%d)", int32(s))
+}
+
+func (s OVRTrackingSpace) valid() bool {
+	_, ok := trackingSpaceNames[s]
+	return ok
+}
+
+// GetTrackingSpace returns the space poses are currently reported in.
+func (c *Context) GetTrackingSpace(vrApp *OVRMobile) (OVRTrackingSpace, error) {
+	var space OVRTrackingSpace
+	err := c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		space = OVRTrackingSpace(C.vrapi_GetTrackingSpace(cOVR))
+		return nil
+	})
+
+	return space, err
+}
+
+// SetTrackingSpace changes the space poses are reported in. LOCAL is the
+// default, room scale apps want LOCAL_FLOOR (or STAGE) so the floor is at
+// y = 0. Both LOCAL spaces move when the system recenters.
+func (c *Context) SetTrackingSpace(vrApp *OVRMobile, space OVRTrackingSpace) error {
+	return c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+		if !space.valid() {
+			return &Error{Op: "vrapi_SetTrackingSpace", Code: OVRError_InvalidParameter}
+		}
+
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		res := C.vrapi_SetTrackingSpace(cOVR, C.ovrTrackingSpace(space))
+		return resultError("vrapi_SetTrackingSpace", OVRResult(res))
+	})
+}
+
+// LocateTrackingSpace returns the pose of target relative to the current
+// tracking space, e.g. locating LOCAL_FLOOR from LOCAL gives the floor
+// height as -Position.Y. The orientation is converted to Hamilton.
+func (c *Context) LocateTrackingSpace(vrApp *OVRMobile, target OVRTrackingSpace) (OVRPosef, error) {
+	var pose OVRPosef
+	err := c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
+		}
+		if !target.valid() {
+			return &Error{Op: "vrapi_LocateTrackingSpace", Code: OVRError_InvalidParameter}
+		}
+
+		cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+		cPose := C.vrapi_LocateTrackingSpace(cOVR, C.ovrTrackingSpace(target))
+		pose = *(*OVRPosef)(unsafe.Pointer(&cPose))
+		posefToHamilton(&pose)
+		return nil
+	})
+
+	return pose, err
+}
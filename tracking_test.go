@@ -0,0 +1,71 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestTrackingSpace(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+
+	if space, err := c.GetTrackingSpace(vrApp); err != nil || space != TRACKING_SPACE_LOCAL {
+		t.Errorf("GetTrackingSpace() = %v, %v, want LOCAL", space, err)
+	}
+	if err := c.SetTrackingSpace(vrApp, TRACKING_SPACE_LOCAL_FLOOR); err != nil {
+		t.Errorf("SetTrackingSpace: %v", err)
+	}
+	if space, err := c.GetTrackingSpace(vrApp); err != nil || space != TRACKING_SPACE_LOCAL_FLOOR {
+		t.Errorf("GetTrackingSpace() = %v, %v, want LOCAL_FLOOR", space, err)
+	}
+
+	var vrapiErr *Error
+	err := c.SetTrackingSpace(vrApp, OVRTrackingSpace(5))
+	if !errors.As(err, &vrapiErr) || vrapiErr.Code != OVRError_InvalidParameter {
+		t.Errorf("SetTrackingSpace with an unknown space returned %v", err)
+	}
+	if space := fakevrapi.GetState().TrackingSpace; space != int(TRACKING_SPACE_LOCAL_FLOOR) {
+		t.Errorf("unknown space reached the runtime, tracking space is %d", space)
+	}
+
+	// The stage is turned 90 degrees around Y and its floor is 1.5m below.
+	s, cos := float32(math.Sin(math.Pi/4)), float32(math.Cos(math.Pi/4))
+	fakevrapi.SetLocatedPose([4]float32{0, s, 0, cos}, [3]float32{0, -1.5, 0})
+	pose, err := c.LocateTrackingSpace(vrApp, TRACKING_SPACE_STAGE)
+	if err != nil {
+		t.Fatalf("LocateTrackingSpace: %v", err)
+	}
+	want := OVRPosef{
+		Orientation: mgl.Quat{W: cos, V: mgl.Vec3{0, s, 0}},
+		Position:    mgl.Vec3{0, -1.5, 0},
+	}
+	if pose != want {
+		t.Errorf("LocateTrackingSpace() = %v, want %v", pose, want)
+	}
+	if space := fakevrapi.GetState().LocatedSpace; space != int(TRACKING_SPACE_STAGE) {
+		t.Errorf("runtime located space %d, want STAGE", space)
+	}
+	if _, err := c.LocateTrackingSpace(vrApp, OVRTrackingSpace(5)); !errors.As(err, &vrapiErr) {
+		t.Errorf("LocateTrackingSpace with an unknown space returned %v", err)
+	}
+
+	if err := c.LeaveVrMode(vrApp); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetTrackingSpace(vrApp); !errors.Is(err, ErrLeftVrMode) {
+		t.Errorf("GetTrackingSpace after LeaveVrMode returned %v", err)
+	}
+	if err := c.SetTrackingSpace(vrApp, TRACKING_SPACE_STAGE); !errors.Is(err, ErrLeftVrMode) {
+		t.Errorf("SetTrackingSpace after LeaveVrMode returned %v", err)
+	}
+	if _, err := c.LocateTrackingSpace(vrApp, TRACKING_SPACE_STAGE); !errors.Is(err, ErrLeftVrMode) {
+		t.Errorf("LocateTrackingSpace after LeaveVrMode returned %v", err)
+	}
+}
@@ -1,29 +1,50 @@
 //go:build darwin || linux || windows
-// +build android, gldebug
+
 package vrapi
 
 /*
 #cgo CPPFLAGS: -I./Include -I/usr/local/include
-#cgo LDFLAGS: -v -march=armv8-a -shared -L./lib/arm64-v8a/ -lvrapi -landroid
+#cgo android LDFLAGS: -v -march=armv8-a -shared -L./lib/arm64-v8a/ -lvrapi -landroid
 
 #include <VrApi.h>
 #include <VrApi_Helpers.h>
 #include <VrApi_Input.h>
 
-ovrResult submit(ovrMobile* ovr, ovrSubmitFrameDescription2* frameDesc, ovrLayerProjection2 layer) {
-//ovrResult submit(ovrMobile* ovr, ovrSubmitFrameDescription2* frameDesc, ovrLayerHeader2* layer) {
-
-	// Sets a C pointer to a C pointer?
-	const ovrLayerHeader2* layers[] = { &layer.Header };
+#include <stdlib.h>
+
+// layerSize returns the size of the C layer struct for the given layer type
+// or zero if the layer type is not supported.
+static size_t layerSize(ovrLayerType2 type) {
+	switch (type) {
+	case VRAPI_LAYER_TYPE_PROJECTION2:
+		return sizeof(ovrLayerProjection2);
+	case VRAPI_LAYER_TYPE_CYLINDER2:
+		return sizeof(ovrLayerCylinder2);
+	case VRAPI_LAYER_TYPE_CUBE2:
+		return sizeof(ovrLayerCube2);
+	case VRAPI_LAYER_TYPE_EQUIRECT2:
+		return sizeof(ovrLayerEquirect2);
+	case VRAPI_LAYER_TYPE_EQUIRECT3:
+		return sizeof(ovrLayerEquirect3);
+	case VRAPI_LAYER_TYPE_LOADING_ICON2:
+		return sizeof(ovrLayerLoadingIcon2);
+	case VRAPI_LAYER_TYPE_FISHEYE2:
+		return sizeof(ovrLayerFishEye2);
+	default:
+		return 0;
+	}
+}
 
-	//const ovrLayerHeader2* layers[] = { layer };
-	(*frameDesc).Layers = layers;
-    return vrapi_SubmitFrame2(ovr, frameDesc);
+// swapChain turns an OVRTextureSwapChain handle back into the pointer it was
+// made from. Go only ever holds the handle as an integer.
+static ovrTextureSwapChain* swapChain(uintptr_t handle) {
+	return (ovrTextureSwapChain*)handle;
 }
 */
 import "C"
 
 import (
+	"errors"
 	"fmt"
 	"unsafe"
 
@@ -43,11 +64,9 @@ func jplToHamiltonQuats(quat mgl.Quat) mgl.Quat {
 // Should we not get these from runtime?
 // Should we get all constants from runtime?
 const (
-	INITIALIZE_SUCCESS = C.VRAPI_INITIALIZE_SUCCESS
-
-	OVRSuccess = C.ovrSuccess
-
 	FRAME_LAYER_EYE_MAX = C.VRAPI_FRAME_LAYER_EYE_MAX
+
+	MAX_LAYER_COUNT = C.ovrMaxLayerCount
 )
 
 func GetSystemPropertyInt(java *OVRJava, parm OVRSystemProperty) int { // int or int32?
@@ -55,15 +74,47 @@ func GetSystemPropertyInt(java *OVRJava, parm OVRSystemProperty) int { // int or
 	return int(C.vrapi_GetSystemPropertyInt(cJava, C.ovrSystemProperty(parm)))
 }
 
+// Unlike system properties these can be set. Only valid once vrapi is
+// initialized.
+func SetPropertyInt(java *OVRJava, prop OVRProperty, val int) {
+	C.vrapi_SetPropertyInt((*C.ovrJava)(java), C.ovrProperty(prop), C.int(val))
+}
+
+// GetPropertyInt returns false if the property can not be read.
+func GetPropertyInt(java *OVRJava, prop OVRProperty) (int, bool) {
+	var val C.int
+	ok := C.vrapi_GetPropertyInt((*C.ovrJava)(java), C.ovrProperty(prop), &val)
+	return int(val), bool(ok)
+}
+
+// GetSupportedSwapChainFormats returns the formats swap chains can be created
+// with on this device, decoded from SYS_PROP_SUPPORTED_SWAPCHAIN_FORMATS.
+func GetSupportedSwapChainFormats(java *OVRJava) []SwapChainFormat {
+	count := GetSystemPropertyInt(java, SYS_PROP_NUM_SUPPORTED_SWAPCHAIN_FORMATS)
+	if count <= 0 {
+		return nil
+	}
+
+	formats := make([]SwapChainFormat, count)
+	n := C.vrapi_GetSystemPropertyInt64Array((*C.ovrJava)(java),
+		C.ovrSystemProperty(SYS_PROP_SUPPORTED_SWAPCHAIN_FORMATS),
+		(*C.int64_t)(unsafe.Pointer(&formats[0])), C.int(count))
+	if n < 0 {
+		return nil
+	}
+
+	return formats[:int(n)]
+}
+
 type OVRInitParms C.ovrInitParms // HMMM alias this type?
 
 //type OVRModeParms C.ovrModeParms
 // Just experiment with this?
 type OVRModeParms struct {
-	Type  OVRStructureType
-	Flags OVRModeFlags
-	Java  OVRJava
-	//Padding       int32 // ??? // Add in build constraint for padding here?
+	Type          OVRStructureType
+	Flags         OVRModeFlags
+	Java          OVRJava
+	_             padding32Bit
 	Display       uint64
 	WindowSurface uint64
 	ShareContext  uint64
@@ -82,6 +133,17 @@ type OVRTracking2 struct {
 	Eye      [2]Tracking2Matrices
 }
 
+// Reports the status and pose of a motion tracker, e.g. a controller from
+// GetInputTrackingState.
+type OVRTracking struct {
+	Status  uint32 // Sensor status described by ovrTrackingStatus flags.
+	Padding [4]byte
+
+	// Predicted configuration at the requested absolute time. Despite the
+	// name it is the pose of whatever device was asked for.
+	HeadPose OVRRigidBodyPosef
+}
+
 type Tracking2Matrices struct {
 	ProjectionMatrix mgl.Mat4
 	ViewMatrix       mgl.Mat4
@@ -108,8 +170,7 @@ type OVRLayerHeader2 struct {
 
 type OVRLayerProjection2 struct {
 	Header OVRLayerHeader2
-	// TODO padding for 32 bit
-	//Padding       int32 // ??? // Add in build constraint for padding here?
+	_      padding32Bit
 
 	HeadPose OVRRigidBodyPosef // TODO
 	// We have to conver to, then convert back upon submission supposedly?
@@ -124,163 +185,353 @@ type OVRSubmitFrameDescription2 struct {
 	DisplayTime  float64
 	Pad          [8]byte // Unused
 	LayerCount   uint32
-	Layers       []*OVRLayerHeader2 // Only supports single layers for now
-
-	//Layers       [1]*OVRLayerHeader2
-	//Layers       []OVRLayerHeader2 // TODO when calling stuff pass a pointer to first element
-}
-
-type Context struct {
-	workAvailable chan<- struct{}
-	work          chan<- func()
-	workDone      <-chan struct{}
-}
-
-func NewContext() (Context, Worker) {
-	workAvailable := make(chan struct{}, 1)
-	work := make(chan func(), 1)
-	workDone := make(chan struct{})
-
-	c := Context{
-		workAvailable: workAvailable,
-		work:          work,
-		workDone:      workDone,
-	}
-	w := Worker{
-		workAvailable: workAvailable,
-		work:          work,
-		workDone:      workDone,
-	}
-
-	return c, w
+	// Pointers to each layer (e.g. &projection). The first LayerCount layers
+	// are copied into C memory upon submission.
+	Layers []Layer
 }
 
-type Worker struct {
-	workAvailable <-chan struct{}
-	work          <-chan func()
-	workDone      chan<- struct{}
+// Layer is implemented by a pointer to each of the layer types,
+// *OVRLayerProjection2, *OVRLayerCylinder2 and so on. Only the types in this
+// package implement it since SubmitFrame2 copies the whole value into C
+// memory and relies on the layout checks in layout.go for its size.
+type Layer interface {
+	header() *OVRLayerHeader2
+	// size of the Go value, which is also the size of the C struct.
+	size() uintptr
+	// swapChains created by the application the layer references.
+	swapChains() []OVRTextureSwapChain
 }
 
-func (w *Worker) WorkAvailable() <-chan struct{} {
-	return w.workAvailable
+// LayerCountError is returned by SubmitFrame2 when a frame description
+// asks for more layers than the runtime supports.
+type LayerCountError struct {
+	LayerCount int
+	Max        int
 }
 
-func (w *Worker) DoWork() {
-	// (<-w.work)() would be so much more confusing...
-	fun := <-w.work
-	fun()
-	w.workDone <- struct{}{}
+func (e *LayerCountError) Error() string {
+	return fmt.Sprintf("submit frame layer count %d exceeds maximum of %d",
+		e.LayerCount, e.Max)
 }
 
 func (c *Context) EnterVrMode(modeParms *OVRModeParms) *OVRMobile {
 	var ovr *OVRMobile
-	c.work <- func() {
+	c.Do(func() {
 		cParms := (*C.ovrModeParms)(unsafe.Pointer(modeParms))
 		cOVR := C.vrapi_EnterVrMode(cParms)
 		ovr = (*OVRMobile)(cOVR)
-	}
-	c.workAvailable <- struct{}{}
-	<-c.workDone
+		if ovr != nil {
+			c.state.mobiles[ovr] = true
+		}
+	})
 
 	return ovr
 }
 
 func (c *Context) Initialize(parms *OVRInitParms) error {
-	var err error
-	c.work <- func() {
-		status := C.vrapi_Initialize((*C.ovrInitParms)(parms))
+	return c.doErr(func() error {
+		status := OVRInitializeStatus(C.vrapi_Initialize((*C.ovrInitParms)(parms)))
 		if status != INITIALIZE_SUCCESS {
-			err = fmt.Errorf("vrapi_Initialize status %d not equal to sucess %d",
-				status, INITIALIZE_SUCCESS)
+			return &Error{Op: "vrapi_Initialize", Code: status}
+		}
+		c.state.initialized = true
+		java := OVRJava(parms.Java)
+		c.state.java = &java
+		return nil
+	})
+}
+
+// LeaveVrMode must be called when the Android activity is paused or the
+// Android surface is destroyed or changed. vrApp can not be used afterwards.
+//
+// Only the Context methods check for that and return ErrLeftVrMode. The
+// functions that take vrApp directly (GetPredictedDisplayTime,
+// GetPredictedTracking2, the input, haptics and hand functions) are not
+// guarded and hand a departed vrApp straight to the runtime, so stop calling
+// them before LeaveVrMode, e.g. by getting vrApp from Session.Mobile each
+// frame.
+func (c *Context) LeaveVrMode(vrApp *OVRMobile) error {
+	return c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
 		}
-	}
-	c.workAvailable <- struct{}{}
-	<-c.workDone
 
-	return err
+		C.vrapi_LeaveVrMode((*C.ovrMobile)(unsafe.Pointer(vrApp)))
+		c.state.mobiles[vrApp] = false
+		return nil
+	})
 }
 
-func (c *Context) CreateTextureSwapChain3(texType OVRTextureType, format int64,
-	width, height, levels, bufferCount int) *OVRTextureSwapChain {
+// Shutdown shuts down the API. Every OVRMobile must have left vr mode first.
+func (c *Context) Shutdown() error {
+	return c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+		if n := c.state.inVrMode(); n != 0 {
+			return fmt.Errorf("vrapi_Shutdown called with %d OVRMobile still in vr mode", n)
+		}
+		if c.state.vulkan {
+			return errors.New("vrapi_Shutdown called before DestroySystemVulkan")
+		}
+
+		C.vrapi_Shutdown()
+		c.state.initialized = false
 
-	var swapChain *OVRTextureSwapChain
-	c.work <- func() {
+		if len(c.state.swapChains) != 0 {
+			leaked := &SwapChainLeakError{}
+			for swapChain := range c.state.swapChains {
+				leaked.SwapChains = append(leaked.SwapChains, swapChain)
+			}
+			c.state.swapChains = make(map[OVRTextureSwapChain]SwapChainFormat)
+			return leaked
+		}
+		return nil
+	})
+}
+
+func (c *Context) CreateTextureSwapChain3(texType OVRTextureType, format SwapChainFormat,
+	width, height, levels, bufferCount int) OVRTextureSwapChain {
+
+	var swapChain OVRTextureSwapChain
+	c.Do(func() {
 		cSwapChain := C.vrapi_CreateTextureSwapChain3(
 			C.ovrTextureType(texType), C.long(format),
 			C.int(width), C.int(height), C.int(levels), C.int(bufferCount))
-		swapChain = (*OVRTextureSwapChain)(unsafe.Pointer(cSwapChain))
-	}
-	c.workAvailable <- struct{}{}
-	<-c.workDone
+		swapChain = newSwapChain(cSwapChain)
+		c.state.addSwapChain(swapChain, format)
+	})
 
 	return swapChain
 }
 
-func (c *Context) GetTextureSwapChainLength(swapChain *OVRTextureSwapChain) int {
+// Mirrors ovrSwapChainCreateInfo.
+type SwapChainCreateInfo struct {
+	// Must be one of the formats GetSupportedSwapChainFormats reports. With
+	// the Vulkan system these are VkFormat values, e.g.
+	// SwapChainFormat(VK_FORMAT_R8G8B8A8_SRGB).
+	Format SwapChainFormat
+
+	Width  int32
+	Height int32
+	// The number of levels of detail available for minified sampling.
+	Levels int32
+	// Either 6 (for cubemaps) or 1.
+	FaceCount int32
+	// 1 for 2D textures, 2 for a 2D texture array (multiview).
+	ArraySize int32
+	// Number of buffers in the texture swap chain.
+	BufferCount int32
+
+	CreateFlags OVRSwapChainCreateFlags
+	// Use SWAPCHAIN_USAGE_DEPTH_STENCIL_ATTACHMENT_BIT with a depth format for
+	// depth swap chains. There is no sample count, multisampled eye buffers
+	// are rendered with EXT_multisampled_render_to_texture into a single
+	// sample swap chain.
+	UsageFlags OVRSwapChainUsageFlags
+}
+
+// CreateTextureSwapChain4 validates info, including that the format is
+// supported by the system, before creating the swap chain. Initialize must
+// have been called first.
+func (c *Context) CreateTextureSwapChain4(info *SwapChainCreateInfo) (OVRTextureSwapChain, error) {
+	var swapChain OVRTextureSwapChain
+	err := c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+		if err := info.validate(GetSupportedSwapChainFormats(c.state.java)); err != nil {
+			return err
+		}
+
+		cInfo := (*C.ovrSwapChainCreateInfo)(unsafe.Pointer(info))
+		swapChain = newSwapChain(C.vrapi_CreateTextureSwapChain4(cInfo))
+		if swapChain == 0 {
+			return fmt.Errorf("vrapi_CreateTextureSwapChain4 failed for %+v", *info)
+		}
+		c.state.addSwapChain(swapChain, info.Format)
+		return nil
+	})
+
+	return swapChain, err
+}
+
+func (info *SwapChainCreateInfo) validate(supportedFormats []SwapChainFormat) error {
+	supported := false
+	for _, format := range supportedFormats {
+		if format == info.Format {
+			supported = true
+			break
+		}
+	}
+
+	switch {
+	case !supported:
+		return fmt.Errorf("swap chain format %v not in supported formats %v",
+			info.Format, supportedFormats)
+	case info.Width <= 0 || info.Height <= 0:
+		return fmt.Errorf("swap chain size %dx%d must be positive", info.Width, info.Height)
+	case info.Levels < 1:
+		return fmt.Errorf("swap chain levels %d must be at least 1", info.Levels)
+	case info.FaceCount != 1 && info.FaceCount != 6:
+		return fmt.Errorf("swap chain face count %d must be 1 or 6", info.FaceCount)
+	case info.ArraySize < 1:
+		return fmt.Errorf("swap chain array size %d must be at least 1", info.ArraySize)
+	case info.BufferCount < 1:
+		return fmt.Errorf("swap chain buffer count %d must be at least 1", info.BufferCount)
+	}
+	return nil
+}
+
+// DestroyTextureSwapChain must be called before the EGL context the swap
+// chain was created with is destroyed. swapChain can not be used afterwards.
+func (c *Context) DestroyTextureSwapChain(swapChain OVRTextureSwapChain) error {
+	return c.doErr(func() error {
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+
+		C.vrapi_DestroyTextureSwapChain(swapChain.c())
+		delete(c.state.swapChains, swapChain)
+		return nil
+	})
+}
+
+func (c *Context) GetTextureSwapChainLength(swapChain OVRTextureSwapChain) (int, error) {
 	var length int
-	c.work <- func() {
-		cSwapChain := (*C.ovrTextureSwapChain)(unsafe.Pointer(swapChain))
+	err := c.doErr(func() error {
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+
+		cSwapChain := swapChain.c()
 		length = int(C.vrapi_GetTextureSwapChainLength(cSwapChain))
-	}
-	c.workAvailable <- struct{}{}
-	<-c.workDone
+		return nil
+	})
 
-	return length
+	return length, err
 }
 
-func (c *Context) GetTextureSwapChainHandle(swapChain *OVRTextureSwapChain, i int) uint32 {
+func (c *Context) GetTextureSwapChainHandle(swapChain OVRTextureSwapChain, i int) (uint32, error) {
 	var handle uint32
-	c.work <- func() {
-		cSwapChain := (*C.ovrTextureSwapChain)(unsafe.Pointer(swapChain))
+	err := c.doErr(func() error {
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+
+		cSwapChain := swapChain.c()
 		handle = uint32(C.vrapi_GetTextureSwapChainHandle(cSwapChain, C.int(i)))
-	}
-	c.workAvailable <- struct{}{}
-	<-c.workDone
+		return nil
+	})
 
-	return handle
+	return handle, err
 }
 
 func (c *Context) SubmitFrame2(vrApp *OVRMobile, frameDesc *OVRSubmitFrameDescription2) error {
-	var err error
-	c.work <- func() {
-		// TODO fix this constaint (allow multiple layers possibly using "varadic" C functions)
-		if len(frameDesc.Layers) != 1 {
-			err = fmt.Errorf("TODO not implmeneted layers must be size 1 for now passed in %+v",
-				frameDesc.Layers)
-			return
+	return c.doErr(func() error {
+		if err := c.state.checkMobile(vrApp); err != nil {
+			return err
 		}
 
-		// Save layers and clear out layer field of frame description
-		layers := frameDesc.Layers
-		frameDesc.Layers = nil
+		count := int(frameDesc.LayerCount)
+		if count > MAX_LAYER_COUNT {
+			return &LayerCountError{LayerCount: count, Max: MAX_LAYER_COUNT}
+		}
+		if count > len(frameDesc.Layers) {
+			return fmt.Errorf("submit frame layer count %d but only %d layers passed in",
+				count, len(frameDesc.Layers))
+		}
+		for i, layer := range frameDesc.Layers[:count] {
+			if layer == nil {
+				continue // Reported by marshalLayers.
+			}
+			for _, swapChain := range layer.swapChains() {
+				if err := c.state.checkSwapChain(swapChain); err != nil {
+					return fmt.Errorf("submit frame layer %d: %w", i, err)
+				}
+			}
+		}
 
-		// Think we should be good with casting this memory? Even though Layers is
-		// a slice in Go? We can just overwrite the memory with the Go pointer, and
-		// the slice will always be larger than the pointer?
-		cFrameDesc := (*C.ovrSubmitFrameDescription2)(unsafe.Pointer(frameDesc))
+		cLayers, free, err := marshalLayers(frameDesc.Layers[:count])
+		if err != nil {
+			return err
+		}
+		defer free()
+
+		cFrameDesc := C.ovrSubmitFrameDescription2{
+			Flags:        C.uint32_t(frameDesc.Flags),
+			SwapInterval: C.uint32_t(frameDesc.SwapInterval),
+			FrameIndex:   C.uint64_t(frameDesc.FrameIndex),
+			DisplayTime:  C.double(frameDesc.DisplayTime),
+			LayerCount:   C.uint32_t(count),
+			Layers:       cLayers,
+		}
 
 		cApp := (*C.ovrMobile)(unsafe.Pointer(vrApp))
-		cLayer := *(*C.ovrLayerProjection2)(unsafe.Pointer(layers[0]))
+		res := C.vrapi_SubmitFrame2(cApp, &cFrameDesc)
+		return resultError("vrapi_SubmitFrame2", OVRResult(res))
+	})
+}
+
+// marshalLayers copies each layer into C memory, since the runtime is handed
+// an array of pointers and cgo does not allow C memory to hold Go pointers.
+// The returned function frees everything that was allocated.
+func marshalLayers(layers []Layer) (**C.ovrLayerHeader2, func(), error) {
+	if len(layers) == 0 {
+		return nil, func() {}, nil
+	}
 
-		res := C.submit(cApp, cFrameDesc, cLayer)
-		frameDesc.Layers = layers
+	ptrSize := C.size_t(unsafe.Sizeof((*C.ovrLayerHeader2)(nil)))
+	cArray := (*[MAX_LAYER_COUNT]*C.ovrLayerHeader2)(C.calloc(C.size_t(len(layers)), ptrSize))
+	free := func() {
+		for i := range layers {
+			C.free(unsafe.Pointer(cArray[i]))
+		}
+		C.free(unsafe.Pointer(cArray))
+	}
+
+	for i, layer := range layers {
+		if layer == nil {
+			free()
+			return nil, nil, fmt.Errorf("submit frame layer %d is nil", i)
+		}
 
-		if res != OVRSuccess {
-			err = fmt.Errorf("get current input state expected sucess (%d) got %d",
-				OVRSuccess, res)
-			return
+		header := layer.header()
+		size := layer.size()
+		if cSize := C.layerSize(C.ovrLayerType2(header.Type)); uintptr(cSize) != size {
+			free()
+			return nil, nil, fmt.Errorf("submit frame layer %d has type %d which does not match %T",
+				i, header.Type, layer)
 		}
+
+		// Copied on the Go side, passing the layer to memcpy would have cgo
+		// check every pointer in whatever Go value the layer is part of.
+		cLayer := C.malloc(C.size_t(size))
+		copy(unsafe.Slice((*byte)(cLayer), size), unsafe.Slice((*byte)(unsafe.Pointer(header)), size))
+		cArray[i] = (*C.ovrLayerHeader2)(cLayer)
 	}
-	c.workAvailable <- struct{}{}
-	<-c.workDone
 
-	return err
+	return &cArray[0], free, nil
 }
 
-type OVRTextureSwapChain C.ovrTextureSwapChain // TODO what is this type???
+// OVRTextureSwapChain is a handle to a swap chain owned by the runtime, 0 for
+// none. It is the address of the C swap chain or one of the
+// DEFAULT_TEXTURE_SWAPCHAIN values, which are not addresses at all, so it is
+// kept as an integer that the garbage collector never treats as a pointer.
+type OVRTextureSwapChain uintptr
+
+func newSwapChain(cSwapChain *C.ovrTextureSwapChain) OVRTextureSwapChain {
+	return OVRTextureSwapChain(uintptr(unsafe.Pointer(cSwapChain)))
+}
+
+// c returns the C swap chain. Only valid for live swap chains, see
+// contextState.checkSwapChain.
+func (s OVRTextureSwapChain) c() *C.ovrTextureSwapChain {
+	return C.swapChain(C.uintptr_t(s))
+}
 
 type EyeInformation struct {
-	ColorSwapChain         *OVRTextureSwapChain
+	ColorSwapChain         OVRTextureSwapChain
 	SwapChainIndex         int32
 	TexCoordsFromTanAngles mgl.Mat4
 	TextureRect            OVRRectf
@@ -292,6 +543,7 @@ type OVRRigidBodyPosef struct {
 	LinearVelocity      mgl.Vec3
 	AngularAcceleration mgl.Vec3
 	LinearAcceleration  mgl.Vec3
+	Padding             [4]byte
 
 	TimeInSeconds       float64 //< Absolute time of this pose.
 	PredictionInSeconds float64 //< Seconds this pose was predicted ahead.
@@ -311,6 +563,34 @@ func DefaultLayerProjection2() OVRLayerProjection2 {
 	return layer
 }
 
+// A black frame, useful while loading a scene. Textures point at the
+// runtime's built in DEFAULT_TEXTURE_SWAPCHAIN so no swap chain needs to be
+// created by the application.
+func DefaultLayerBlackProjection2() OVRLayerProjection2 {
+	cLayer := C.vrapi_DefaultLayerBlackProjection2()
+	layer := *(*OVRLayerProjection2)(unsafe.Pointer(&cLayer))
+
+	return layer
+}
+
+// Same as DefaultLayerBlackProjection2 but the frame is tinted by colorScale.
+func DefaultLayerSolidColorProjection2(colorScale mgl.Vec4) OVRLayerProjection2 {
+	cColorScale := *(*C.ovrVector4f)(unsafe.Pointer(&colorScale))
+	cLayer := C.vrapi_DefaultLayerSolidColorProjection2(&cColorScale)
+	layer := *(*OVRLayerProjection2)(unsafe.Pointer(&cLayer))
+
+	return layer
+}
+
+// IsDefaultTextureSwapChain reports whether swapChain is one of the runtime's
+// built in swap chains rather than one created by the application.
+func IsDefaultTextureSwapChain(swapChain OVRTextureSwapChain) bool {
+	return swapChain == DEFAULT_TEXTURE_SWAPCHAIN ||
+		swapChain == DEFAULT_TEXTURE_SWAPCHAIN_LOADING_ICON
+}
+
+// GetPredictedDisplayTime and GetPredictedTracking2 do not go through the
+// Context, vrApp must still be in vr mode, see LeaveVrMode.
 func GetPredictedDisplayTime(vrApp *OVRMobile, frameIndex int64) float64 {
 	return float64(C.vrapi_GetPredictedDisplayTime((*C.ovrMobile)(vrApp),
 		C.longlong(frameIndex)))
@@ -333,25 +613,22 @@ type OVRInputCapabilityHeader struct {
 	DeviceID OVRDeviceID
 }
 
-// TODO should this return an error?
+// EnumerateInputDevices fills in capsHeader for the device at index. Start
+// at 0 and count up until it returns an error, see InputDevices for a
+// registry that does this every frame.
 func EnumerateInputDevices(vrApp *OVRMobile, index uint32,
-	capsHeader *OVRInputCapabilityHeader) int32 {
+	capsHeader *OVRInputCapabilityHeader) error {
 
 	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
 	cHeader := (*C.ovrInputCapabilityHeader)(unsafe.Pointer(capsHeader))
 	res := C.vrapi_EnumerateInputDevices(cOVR, C.uint(index), cHeader)
-
-	//log.Printf("cHeader %+v %p", cHeader, cHeader)
-	//capsHeader = (*OVRInputCapabilityHeader)(unsafe.Pointer(cHeader)) // Convert back?
-	//log.Printf("goHeader %+v %p %+v", capsHeader, capsHeader, capsHeader.Type)
-
-	return int32(res)
+	return resultError("vrapi_EnumerateInputDevices", OVRResult(res))
 }
 
 type OVRInputStateTrackedRemote struct {
 	Header OVRInputStateHeader
 
-	Buttons uint32 // Values for buttons described by ovrButton.
+	Buttons OVRButton // Values for buttons described by ovrButton.
 	// Finger contact status for trackpad
 	// true = finger is on trackpad, false = finger is off trackpad
 	TrackpadStatus uint32
@@ -370,7 +647,7 @@ type OVRInputStateTrackedRemote struct {
 	GripTrigger  float32
 
 	// added in API version 1.1.15.0
-	Touches    uint32
+	Touches    OVRTouch
 	Reserved5a uint32
 
 	// Analog values from -1.0 - 1.0
@@ -378,6 +655,7 @@ type OVRInputStateTrackedRemote struct {
 	Joystick mgl.Vec2
 	// JoystickNoDeadZone does change the raw values of the data.
 	JoystickNoDeadZone mgl.Vec2
+	_                  [4]byte // C pads the struct to a multiple of 8 bytes.
 }
 
 type OVRInputStateStandardPointer struct {
@@ -385,12 +663,13 @@ type OVRInputStateStandardPointer struct {
 	PointerPose      OVRPosef // to hamiltoned
 	PointerStrength  float32
 	GripPose         OVRPosef // to hamiltoned
-	InputStateStatus uint32
+	InputStateStatus OVRInputStateStandardPointerStatus
 	Reserved         [20]uint64 // Reserved for future use
 }
 
 type OVRInputStateHeader struct {
 	ControllerType OVRControllerType
+	_              padding32Bit
 	TimeInSeconds  float64
 }
 
@@ -404,41 +683,70 @@ type OVRInputStandardPointerCapabilities struct {
 	ControllerCapabilities OVRControllerCapabilities // Mask of controller capabilities described by ovrControllerCapabilities
 	HapticSamplesMax       uint32                    // Maximum submittable samples for the haptics buffer
 	HapticSampleDurationMS uint32                    // length in milliseconds of a sample in the haptics buffer.
-	Reserved               [20]uint64                // Reserved for future use
-}
-
+	_                      padding32Bit
+	Reserved               [20]uint64 // Reserved for future use
+}
+
+// GetCurrentInputState reads the state of deviceID into the struct that
+// starts with inputState. inputState.ControllerType picks the struct, it
+// must be the type EnumerateInputDevices reported for the device and one
+// with a Go mirror (e.g. OVRInputStateTrackedRemote for
+// OVRControllerType_TrackedRemote). Poses are converted to Hamilton.
+//
+// Like GetPredictedTracking2 this and the other input, hand and haptics
+// functions are called from the frame loop without going through the
+// Context, so none of them check that vrApp is still in vr mode. Callers
+// must stop using vrApp once they call LeaveVrMode.
 func GetCurrentInputState(vrApp *OVRMobile,
 	deviceID OVRDeviceID, inputState *OVRInputStateHeader) error {
 
+	hook, ok := inputStateHooks[inputState.ControllerType]
+	if !ok {
+		return &Error{Op: "vrapi_GetCurrentInputState", Code: OVRError_InvalidParameter}
+	}
+
 	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
 	cInputState := (*C.ovrInputStateHeader)(unsafe.Pointer(inputState))
 	res := C.vrapi_GetCurrentInputState(cOVR, C.uint(deviceID), cInputState)
-	if res != OVRSuccess {
-		return fmt.Errorf("get current input state expected sucess (%d) got %d",
-			OVRSuccess, res)
+	if err := resultError("vrapi_GetCurrentInputState", OVRResult(res)); err != nil {
+		return err
 	}
 
-	if inputState.ControllerType == OVRControllerType_StandardPointer {
-		cPointer := (*OVRInputStateStandardPointer)(unsafe.Pointer(inputState))
-		cPointer.GripPose.Orientation = jplToHamiltonQuats(cPointer.GripPose.Orientation)
-		cPointer.PointerPose.Orientation = jplToHamiltonQuats(cPointer.PointerPose.Orientation)
+	hook(inputState)
+	return nil
+}
+
+// GetInputTrackingState returns the pose of deviceID predicted for absTime,
+// e.g. from GetPredictedDisplayTime, or 0 for the most recent reading. The
+// orientation is converted to Hamilton.
+func GetInputTrackingState(vrApp *OVRMobile, deviceID OVRDeviceID,
+	absTime float64) (OVRTracking, error) {
+
+	var tracking OVRTracking
+	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
+	cTracking := (*C.ovrTracking)(unsafe.Pointer(&tracking))
+	res := C.vrapi_GetInputTrackingState(cOVR, C.uint(deviceID), C.double(absTime), cTracking)
+	if err := resultError("vrapi_GetInputTrackingState", OVRResult(res)); err != nil {
+		return OVRTracking{}, err
 	}
 
-	return nil
+	posefToHamilton(&tracking.HeadPose.Pose)
+	return tracking, nil
 }
 
+// GetInputDeviceCapabilities fills in the capabilities struct that starts
+// with capsHeader, picked by capsHeader.Type like GetCurrentInputState.
 func GetInputDeviceCapabilities(vrApp *OVRMobile,
 	capsHeader *OVRInputCapabilityHeader) error {
 
+	if !inputCapabilityTypes[capsHeader.Type] {
+		return &Error{Op: "vrapi_GetInputDeviceCapabilities", Code: OVRError_InvalidParameter}
+	}
+
 	cOVR := (*C.ovrMobile)(unsafe.Pointer(vrApp))
 	cCapsHeader := (*C.ovrInputCapabilityHeader)(unsafe.Pointer(capsHeader))
 	res := C.vrapi_GetInputDeviceCapabilities(cOVR, cCapsHeader)
-	if res != OVRSuccess {
-		return fmt.Errorf("get input device capabilities expected sucess (%d) got %d",
-			OVRSuccess, res)
-	}
-
-	return nil
+	return resultError("vrapi_GetInputDeviceCapabilities", OVRResult(res))
 }
 
 // END input
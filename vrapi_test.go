@@ -0,0 +1,359 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+// newTestContext returns an initialized Context backed by the fake runtime.
+// The fake is checked for misuse once the test is done.
+func newTestContext(t *testing.T) *Context {
+	t.Helper()
+	fakevrapi.Reset()
+
+	c, w := NewContext()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+
+	java := OVRJava{}
+	initParms := DefaultInitParms(&java)
+	if err := c.Initialize(&initParms); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		if state := fakevrapi.GetState(); state.Misuses != 0 {
+			t.Errorf("fake runtime misused %d times, last: %s",
+				state.Misuses, state.LastMisuse)
+		}
+		fakevrapi.Reset()
+	})
+	return &c
+}
+
+func enterVrMode(t *testing.T, c *Context) *OVRMobile {
+	t.Helper()
+	java := OVRJava{}
+	modeParms := DefaultModeParms(&java)
+	vrApp := c.EnterVrMode(&modeParms)
+	if vrApp == nil {
+		t.Fatal("EnterVrMode returned nil")
+	}
+	return vrApp
+}
+
+func layerBytes(layer Layer) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(layer.header())), layer.size())
+}
+
+func TestSubmitFrame2Layers(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	swapChain := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D, SwapChainFormat(0x8058), 64, 64, 1, 3)
+	defer c.DestroyTextureSwapChain(swapChain)
+
+	projection := DefaultLayerProjection2()
+	for eye := range projection.Textures {
+		projection.Textures[eye].ColorSwapChain = swapChain
+		projection.Textures[eye].SwapChainIndex = int32(eye)
+	}
+	cylinder := DefaultLayerCylinder2()
+	cylinder.Header.ColorScale = [4]float32{0.25, 0.5, 0.75, 1}
+	cylinder.Textures[0].ColorSwapChain = swapChain
+	cylinder.Textures[0].SwapChainIndex = 2
+	cube := DefaultLayerCube2()
+	cube.Header.Flags = 0x20
+
+	layers := []Layer{&cylinder, &projection, &cube}
+	frameDesc := OVRSubmitFrameDescription2{
+		SwapInterval: 1,
+		FrameIndex:   42,
+		DisplayTime:  1.5,
+		LayerCount:   uint32(len(layers)),
+		Layers:       layers,
+	}
+	if err := c.SubmitFrame2(vrApp, &frameDesc); err != nil {
+		t.Fatalf("SubmitFrame2: %v", err)
+	}
+
+	submit := fakevrapi.LastSubmit()
+	if submit.FrameIndex != 42 || submit.DisplayTime != 1.5 || submit.SwapInterval != 1 {
+		t.Errorf("submitted frame %d at %v with swap interval %d, want 42 at 1.5 with 1",
+			submit.FrameIndex, submit.DisplayTime, submit.SwapInterval)
+	}
+	if len(submit.Layers) != len(layers) {
+		t.Fatalf("submitted %d layers, want %d", len(submit.Layers), len(layers))
+	}
+
+	seen := make(map[uintptr]bool)
+	for i, layer := range layers {
+		got := submit.Layers[i]
+		if got.Ptr == 0 {
+			t.Errorf("layer %d: NULL pointer", i)
+		}
+		if seen[got.Ptr] {
+			t.Errorf("layer %d: pointer %#x already used by another layer", i, got.Ptr)
+		}
+		seen[got.Ptr] = true
+		if got.Ptr == uintptr(unsafe.Pointer(layer.header())) {
+			t.Errorf("layer %d: runtime was handed Go memory", i)
+		}
+		if got.Type != int(layer.header().Type) {
+			t.Errorf("layer %d: type %d, want %d", i, got.Type, layer.header().Type)
+		}
+		if !bytes.Equal(got.Bytes, layerBytes(layer)) {
+			t.Errorf("layer %d: runtime saw different bytes than %T", i, layer)
+		}
+	}
+}
+
+func TestSubmitFrame2LayerCount(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	projection := DefaultLayerProjection2()
+
+	var layers []Layer
+	for i := 0; i < MAX_LAYER_COUNT+1; i++ {
+		layers = append(layers, &projection)
+	}
+	frameDesc := OVRSubmitFrameDescription2{LayerCount: uint32(len(layers)), Layers: layers}
+	err := c.SubmitFrame2(vrApp, &frameDesc)
+	var countErr *LayerCountError
+	if !errors.As(err, &countErr) || countErr.LayerCount != MAX_LAYER_COUNT+1 {
+		t.Errorf("SubmitFrame2 with %d layers returned %v, want LayerCountError",
+			len(layers), err)
+	}
+
+	// Only the first LayerCount layers are submitted.
+	frameDesc = OVRSubmitFrameDescription2{LayerCount: 1, Layers: layers}
+	if err := c.SubmitFrame2(vrApp, &frameDesc); err != nil {
+		t.Fatalf("SubmitFrame2: %v", err)
+	}
+	if n := len(fakevrapi.LastSubmit().Layers); n != 1 {
+		t.Errorf("submitted %d layers, want 1", n)
+	}
+
+	if fakevrapi.GetState().Submits != 1 {
+		t.Errorf("%d frames reached the runtime, want 1", fakevrapi.GetState().Submits)
+	}
+}
+
+func TestSubmitFrame2InvalidLayers(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	projection := DefaultLayerProjection2()
+	mislabeled := DefaultLayerProjection2()
+	mislabeled.Header.Type = LAYER_TYPE_CYLINDER2
+
+	tests := []struct {
+		name      string
+		frameDesc OVRSubmitFrameDescription2
+	}{
+		{"too few layers", OVRSubmitFrameDescription2{LayerCount: 2, Layers: []Layer{&projection}}},
+		{"nil layer", OVRSubmitFrameDescription2{LayerCount: 2, Layers: []Layer{&projection, nil}}},
+		{"type mismatch", OVRSubmitFrameDescription2{LayerCount: 1, Layers: []Layer{&mislabeled}}},
+	}
+	for _, test := range tests {
+		if err := c.SubmitFrame2(vrApp, &test.frameDesc); err == nil {
+			t.Errorf("%s: SubmitFrame2 returned nil error", test.name)
+		}
+	}
+
+	if fakevrapi.GetState().Submits != 0 {
+		t.Errorf("%d invalid frames reached the runtime", fakevrapi.GetState().Submits)
+	}
+}
+
+// growStack forces the goroutine stack to be copied, which is where invalid
+// pointers in Go memory used to be found.
+func growStack(n int) int {
+	var pad [256]byte
+	if n == 0 {
+		runtime.GC()
+		return int(pad[0])
+	}
+	return growStack(n-1) + int(pad[n%len(pad)])
+}
+
+func TestDefaultProjectionLayers(t *testing.T) {
+	c := newTestContext(t)
+	vrApp := enterVrMode(t, c)
+	defer c.LeaveVrMode(vrApp)
+
+	black := DefaultLayerBlackProjection2()
+	solid := DefaultLayerSolidColorProjection2(mgl.Vec4{1, 0, 0, 1})
+	growStack(1000)
+
+	for _, layer := range []*OVRLayerProjection2{&black, &solid} {
+		for eye, texture := range layer.Textures {
+			if texture.ColorSwapChain != DEFAULT_TEXTURE_SWAPCHAIN {
+				t.Errorf("eye %d swap chain %#x, want DEFAULT_TEXTURE_SWAPCHAIN",
+					eye, texture.ColorSwapChain)
+			}
+			if !IsDefaultTextureSwapChain(texture.ColorSwapChain) {
+				t.Errorf("eye %d swap chain %#x is not a default swap chain",
+					eye, texture.ColorSwapChain)
+			}
+		}
+	}
+	if solid.Header.ColorScale != (mgl.Vec4{1, 0, 0, 1}) {
+		t.Errorf("solid color scale %v, want red", solid.Header.ColorScale)
+	}
+
+	frameDesc := OVRSubmitFrameDescription2{LayerCount: 2, Layers: []Layer{&black, &solid}}
+	if err := c.SubmitFrame2(vrApp, &frameDesc); err != nil {
+		t.Fatalf("SubmitFrame2: %v", err)
+	}
+	for i, layer := range fakevrapi.LastSubmit().Layers {
+		if !bytes.Equal(layer.Bytes, layerBytes(frameDesc.Layers[i])) {
+			t.Errorf("layer %d: runtime saw different bytes than submitted", i)
+		}
+	}
+
+	// Only the runtime knows what to do with them.
+	if err := c.DestroyTextureSwapChain(DEFAULT_TEXTURE_SWAPCHAIN); err == nil {
+		t.Error("DestroyTextureSwapChain(DEFAULT_TEXTURE_SWAPCHAIN) returned nil error")
+	}
+	if _, err := c.GetTextureSwapChainLength(DEFAULT_TEXTURE_SWAPCHAIN); err == nil {
+		t.Error("GetTextureSwapChainLength(DEFAULT_TEXTURE_SWAPCHAIN) returned nil error")
+	}
+}
+
+func TestVrModeLifecycle(t *testing.T) {
+	c := newTestContext(t)
+
+	first := enterVrMode(t, c)
+	second := enterVrMode(t, c)
+	if n := fakevrapi.GetState().LiveSessions; n != 2 {
+		t.Fatalf("%d live sessions after entering twice, want 2", n)
+	}
+
+	if err := c.LeaveVrMode(first); err != nil {
+		t.Fatalf("LeaveVrMode: %v", err)
+	}
+	if err := c.LeaveVrMode(first); !errors.Is(err, ErrLeftVrMode) {
+		t.Errorf("second LeaveVrMode returned %v, want ErrLeftVrMode", err)
+	}
+	if err := c.LeaveVrMode(nil); err == nil {
+		t.Error("LeaveVrMode(nil) returned nil error")
+	}
+	if n := fakevrapi.GetState().LiveSessions; n != 1 {
+		t.Errorf("%d live sessions after leaving one, want 1", n)
+	}
+
+	frameDesc := OVRSubmitFrameDescription2{}
+	if err := c.SubmitFrame2(first, &frameDesc); !errors.Is(err, ErrLeftVrMode) {
+		t.Errorf("SubmitFrame2 after leaving returned %v, want ErrLeftVrMode", err)
+	}
+	if err := c.SubmitFrame2(second, &frameDesc); err != nil {
+		t.Errorf("SubmitFrame2 on the session still in vr mode: %v", err)
+	}
+	if n := fakevrapi.GetState().Submits; n != 1 {
+		t.Errorf("%d frames reached the runtime, want 1", n)
+	}
+
+	if err := c.Shutdown(); err == nil {
+		t.Error("Shutdown with a session in vr mode returned nil error")
+	}
+	if !fakevrapi.GetState().Initialized {
+		t.Fatal("runtime shut down with a session in vr mode")
+	}
+
+	if err := c.LeaveVrMode(second); err != nil {
+		t.Fatalf("LeaveVrMode: %v", err)
+	}
+	if err := c.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if state := fakevrapi.GetState(); state.Initialized || state.LiveSessions != 0 {
+		t.Errorf("after Shutdown initialized %v with %d live sessions",
+			state.Initialized, state.LiveSessions)
+	}
+	if err := c.Shutdown(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("second Shutdown returned %v, want ErrNotInitialized", err)
+	}
+}
+
+func TestShutdownSwapChainLeak(t *testing.T) {
+	c := newTestContext(t)
+
+	leaked := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D, SwapChainFormat(0x8058), 64, 64, 1, 3)
+	destroyed := c.CreateTextureSwapChain3(TEXTURE_TYPE_2D, SwapChainFormat(0x8058), 64, 64, 1, 3)
+	if err := c.DestroyTextureSwapChain(destroyed); err != nil {
+		t.Fatalf("DestroyTextureSwapChain: %v", err)
+	}
+	if err := c.DestroyTextureSwapChain(destroyed); !errors.Is(err, ErrSwapChainNotLive) {
+		t.Errorf("second DestroyTextureSwapChain returned %v, want ErrSwapChainNotLive", err)
+	}
+
+	err := c.Shutdown()
+	var leakErr *SwapChainLeakError
+	if !errors.As(err, &leakErr) || len(leakErr.SwapChains) != 1 || leakErr.SwapChains[0] != leaked {
+		t.Errorf("Shutdown returned %v, want SwapChainLeakError for %#x", err, leaked)
+	}
+	if fakevrapi.GetState().Initialized {
+		t.Error("runtime not shut down")
+	}
+}
+
+func TestCreateTextureSwapChain4Formats(t *testing.T) {
+	c := newTestContext(t)
+
+	// The fake supports GL_RGBA8 and GL_SRGB8_ALPHA8.
+	info := SwapChainCreateInfo{
+		Format:      SwapChainFormat(0x8C43),
+		Width:       64,
+		Height:      64,
+		Levels:      1,
+		FaceCount:   1,
+		ArraySize:   2,
+		BufferCount: 3,
+	}
+	swapChain, err := c.CreateTextureSwapChain4(&info)
+	if err != nil || swapChain == 0 {
+		t.Fatalf("CreateTextureSwapChain4 with GL_SRGB8_ALPHA8 = %#x, %v", swapChain, err)
+	}
+	if n := fakevrapi.GetState().LiveSwapChains; n != 1 {
+		t.Errorf("%d live swap chains, want 1", n)
+	}
+	if err := c.DestroyTextureSwapChain(swapChain); err != nil {
+		t.Errorf("DestroyTextureSwapChain: %v", err)
+	}
+
+	// GL_SRGB8 without alpha is not supported.
+	info.Format = SwapChainFormat(0x8C41)
+	swapChain, err = c.CreateTextureSwapChain4(&info)
+	if err == nil || !strings.Contains(err.Error(), "not in supported formats") {
+		t.Errorf("CreateTextureSwapChain4 with GL_SRGB8 returned %v, want unsupported format",
+			err)
+	}
+	if swapChain != 0 {
+		t.Errorf("CreateTextureSwapChain4 with GL_SRGB8 returned swap chain %#x", swapChain)
+	}
+	if n := fakevrapi.GetState().LiveSwapChains; n != 0 {
+		t.Errorf("runtime created %d swap chains for an unsupported format", n)
+	}
+}
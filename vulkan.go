@@ -0,0 +1,252 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+/*
+#include <stdlib.h>
+#include <VrApi.h>
+#include <VrApi_Helpers.h>
+#include <VrApi_Vulkan.h>
+
+// VkImage is a pointer on 64 bit and a uint64_t on 32 bit.
+static inline uint64_t vkImageHandle(VkImage image) {
+	return (uint64_t)image;
+}
+
+// createSystemVulkan turns the handles back into the pointers they were made
+// from. Go only ever holds them as integers.
+static ovrResult createSystemVulkan(uintptr_t instance, uintptr_t physicalDevice,
+	uintptr_t device) {
+	ovrSystemCreateInfoVulkan info;
+	info.Instance = (VkInstance)instance;
+	info.PhysicalDevice = (VkPhysicalDevice)physicalDevice;
+	info.Device = (VkDevice)device;
+	return vrapi_CreateSystemVulkan(&info);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// The Vulkan path replaces the EGL parts of the GLES one:
+//
+//	Initialize
+//	GetInstanceExtensionsVulkan / GetDeviceExtensionsVulkan, then create
+//	the VkInstance and VkDevice with those extensions enabled
+//	CreateSystemVulkan
+//	CreateTextureSwapChain4 with a VkFormat, GetTextureSwapChainBufferVulkan
+//	EnterVrModeVulkan
+//	...
+//	LeaveVrMode, DestroyTextureSwapChain
+//	DestroySystemVulkan
+//	Shutdown
+
+// VkImage is a non dispatchable Vulkan handle, a uint64 on every platform so
+// it can be converted to the handle type of whichever Vulkan bindings are in
+// use.
+type VkImage uint64
+
+// Initialization parameters unique to Vulkan. The handles are the
+// dispatchable VkInstance, VkPhysicalDevice and VkDevice pointers.
+type OVRSystemCreateInfoVulkan struct {
+	Instance       uintptr
+	PhysicalDevice uintptr
+	Device         uintptr
+}
+
+type OVRModeParmsVulkan struct {
+	ModeParms OVRModeParms
+
+	// The VkQueue created on the same Device as passed to CreateSystemVulkan.
+	// An internally created VkFence is signaled by the completion of commands
+	// on the queue.
+	SynchronizationQueue uint64
+}
+
+func DefaultModeParmsVulkan(java *OVRJava, synchronizationQueue uint64) OVRModeParmsVulkan {
+	cParms := C.vrapi_DefaultModeParmsVulkan((*C.ovrJava)(java),
+		C.ulonglong(synchronizationQueue))
+	return *(*OVRModeParmsVulkan)(unsafe.Pointer(&cParms))
+}
+
+// Large enough for the extension lists of every runtime so far, the same
+// size the VrApi samples use.
+const vulkanExtensionNamesSize = 4096
+
+// GetInstanceExtensionsVulkan returns the extensions that must be enabled on
+// the VkInstance for the runtime to support Vulkan.
+func (c *Context) GetInstanceExtensionsVulkan() ([]string, error) {
+	var extensions []string
+	err := c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+
+		var err error
+		extensions, err = vulkanExtensions("vrapi_GetInstanceExtensionsVulkan",
+			func(names *C.char, size *C.uint32_t) C.ovrResult {
+				return C.vrapi_GetInstanceExtensionsVulkan(names, size)
+			})
+		return err
+	})
+
+	return extensions, err
+}
+
+// GetDeviceExtensionsVulkan returns the extensions that must be enabled on
+// the VkDevice for the runtime to support Vulkan.
+func (c *Context) GetDeviceExtensionsVulkan() ([]string, error) {
+	var extensions []string
+	err := c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+
+		var err error
+		extensions, err = vulkanExtensions("vrapi_GetDeviceExtensionsVulkan",
+			func(names *C.char, size *C.uint32_t) C.ovrResult {
+				return C.vrapi_GetDeviceExtensionsVulkan(names, size)
+			})
+		return err
+	})
+
+	return extensions, err
+}
+
+// vulkanExtensions splits the space delimited list get fills in.
+func vulkanExtensions(op string,
+	get func(names *C.char, size *C.uint32_t) C.ovrResult) ([]string, error) {
+
+	names := (*C.char)(C.calloc(vulkanExtensionNamesSize, 1))
+	defer C.free(unsafe.Pointer(names))
+
+	size := C.uint32_t(vulkanExtensionNamesSize)
+	if err := resultError(op, OVRResult(get(names, &size))); err != nil {
+		return nil, err
+	}
+	if size > vulkanExtensionNamesSize {
+		size = vulkanExtensionNamesSize
+	}
+
+	list := C.GoStringN(names, C.int(size))
+	if i := strings.IndexByte(list, 0); i >= 0 {
+		list = list[:i]
+	}
+	return strings.Fields(list), nil
+}
+
+// CreateSystemVulkan is called after Initialize and before creating swap
+// chains or entering vr mode.
+func (c *Context) CreateSystemVulkan(info *OVRSystemCreateInfoVulkan) error {
+	return c.doErr(func() error {
+		if !c.state.initialized {
+			return ErrNotInitialized
+		}
+		if c.state.vulkan {
+			return errors.New("vrapi_CreateSystemVulkan called twice")
+		}
+
+		res := OVRResult(C.createSystemVulkan(C.uintptr_t(info.Instance),
+			C.uintptr_t(info.PhysicalDevice), C.uintptr_t(info.Device)))
+		if err := resultError("vrapi_CreateSystemVulkan", res); err != nil {
+			return err
+		}
+		c.state.vulkan = true
+		return nil
+	})
+}
+
+// DestroySystemVulkan is called before Shutdown.
+func (c *Context) DestroySystemVulkan() error {
+	return c.doErr(func() error {
+		if !c.state.vulkan {
+			return errors.New("vrapi_DestroySystemVulkan called without CreateSystemVulkan")
+		}
+
+		C.vrapi_DestroySystemVulkan()
+		c.state.vulkan = false
+		return nil
+	})
+}
+
+// EnterVrModeVulkan is EnterVrMode with the Vulkan mode parms, parms should
+// come from DefaultModeParmsVulkan so ModeParms.Type is
+// STRUCTURE_TYPE_MODE_PARMS_VULKAN.
+func (c *Context) EnterVrModeVulkan(parms *OVRModeParmsVulkan) (*OVRMobile, error) {
+	if parms.ModeParms.Type != STRUCTURE_TYPE_MODE_PARMS_VULKAN {
+		return nil, fmt.Errorf("vulkan mode parms have structure type %d", parms.ModeParms.Type)
+	}
+
+	var ovr *OVRMobile
+	err := c.doErr(func() error {
+		if !c.state.vulkan {
+			return errors.New("vrapi_EnterVrMode with vulkan mode parms called before CreateSystemVulkan")
+		}
+
+		// The runtime reads the rest of the struct based on Type.
+		cParms := (*C.ovrModeParms)(unsafe.Pointer(parms))
+		ovr = (*OVRMobile)(C.vrapi_EnterVrMode(cParms))
+		if ovr == nil {
+			return errors.New("vrapi_EnterVrMode failed")
+		}
+		c.state.mobiles[ovr] = true
+		return nil
+	})
+
+	return ovr, err
+}
+
+// GetTextureSwapChainBufferVulkan returns the VkImage of buffer index of a
+// swap chain created while the Vulkan system exists.
+func (c *Context) GetTextureSwapChainBufferVulkan(swapChain OVRTextureSwapChain,
+	index int) (VkImage, error) {
+
+	var image VkImage
+	err := c.doErr(func() error {
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+
+		cSwapChain := swapChain.c()
+		image = VkImage(C.vkImageHandle(
+			C.vrapi_GetTextureSwapChainBufferVulkan(cSwapChain, C.int(index))))
+		if image == 0 {
+			return fmt.Errorf("swap chain %#x has no vulkan image at index %d", swapChain, index)
+		}
+		return nil
+	})
+
+	return image, err
+}
+
+// GetTextureSwapChainBufferFoveationVulkan returns the fragment density map
+// used to foveate buffer index of a swap chain and its size.
+func (c *Context) GetTextureSwapChainBufferFoveationVulkan(swapChain OVRTextureSwapChain,
+	index int) (image VkImage, width, height uint32, err error) {
+
+	err = c.doErr(func() error {
+		if err := c.state.checkSwapChain(swapChain); err != nil {
+			return err
+		}
+
+		var cImage C.VkImage
+		var cWidth, cHeight C.uint32_t
+		cSwapChain := swapChain.c()
+		res := OVRResult(C.vrapi_GetTextureSwapChainBufferFoveationVulkan(cSwapChain, C.int(index),
+			&cImage, &cWidth, &cHeight))
+		if err := resultError("vrapi_GetTextureSwapChainBufferFoveationVulkan", res); err != nil {
+			return err
+		}
+
+		image = VkImage(C.vkImageHandle(cImage))
+		width, height = uint32(cWidth), uint32(cHeight)
+		return nil
+	})
+
+	return image, width, height, err
+}
@@ -0,0 +1,102 @@
+//go:build darwin || linux || windows
+
+package vrapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nicholasblaskey/vrapi/internal/fakevrapi"
+)
+
+func TestVulkanExtensions(t *testing.T) {
+	c := newTestContext(t)
+
+	instance, err := c.GetInstanceExtensionsVulkan()
+	if err != nil || len(instance) != 0 {
+		t.Errorf("GetInstanceExtensionsVulkan() = %q, %v, want none", instance, err)
+	}
+
+	fakevrapi.SetVulkanExtensions("VK_KHR_surface VK_KHR_android_surface",
+		"VK_KHR_swapchain  VK_KHR_external_memory VK_KHR_external_memory_fd")
+	instance, err = c.GetInstanceExtensionsVulkan()
+	if want := []string{"VK_KHR_surface", "VK_KHR_android_surface"}; err != nil ||
+		!reflect.DeepEqual(instance, want) {
+		t.Errorf("GetInstanceExtensionsVulkan() = %q, %v, want %q", instance, err, want)
+	}
+	device, err := c.GetDeviceExtensionsVulkan()
+	if want := []string{"VK_KHR_swapchain", "VK_KHR_external_memory",
+		"VK_KHR_external_memory_fd"}; err != nil || !reflect.DeepEqual(device, want) {
+		t.Errorf("GetDeviceExtensionsVulkan() = %q, %v, want %q", device, err, want)
+	}
+
+	if err := c.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetInstanceExtensionsVulkan(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("GetInstanceExtensionsVulkan after Shutdown returned %v", err)
+	}
+	if _, err := c.GetDeviceExtensionsVulkan(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("GetDeviceExtensionsVulkan after Shutdown returned %v", err)
+	}
+}
+
+func TestEnterVrModeVulkan(t *testing.T) {
+	c := newTestContext(t)
+	java := OVRJava{}
+	const queue = 0x4000
+	parms := DefaultModeParmsVulkan(&java, queue)
+
+	if _, err := c.EnterVrModeVulkan(&parms); err == nil {
+		t.Error("EnterVrModeVulkan before CreateSystemVulkan succeeded")
+	}
+
+	info := OVRSystemCreateInfoVulkan{Instance: 0x1000, PhysicalDevice: 0x2000, Device: 0x3000}
+	if err := c.CreateSystemVulkan(&info); err != nil {
+		t.Fatalf("CreateSystemVulkan: %v", err)
+	}
+	state := fakevrapi.GetState()
+	if !state.VulkanSystem || state.VulkanInstance != info.Instance ||
+		state.VulkanPhysicalDevice != info.PhysicalDevice || state.VulkanDevice != info.Device {
+		t.Errorf("runtime got the Vulkan handles %#x, %#x and %#x, want %#x, %#x and %#x",
+			state.VulkanInstance, state.VulkanPhysicalDevice, state.VulkanDevice,
+			info.Instance, info.PhysicalDevice, info.Device)
+	}
+	if err := c.CreateSystemVulkan(&info); err == nil {
+		t.Error("second CreateSystemVulkan succeeded")
+	}
+
+	glesParms := OVRModeParmsVulkan{ModeParms: DefaultModeParms(&java)}
+	if _, err := c.EnterVrModeVulkan(&glesParms); err == nil {
+		t.Error("EnterVrModeVulkan with GLES mode parms succeeded")
+	}
+
+	vrApp, err := c.EnterVrModeVulkan(&parms)
+	if err != nil {
+		t.Fatalf("EnterVrModeVulkan: %v", err)
+	}
+	if state := fakevrapi.GetState(); state.LiveSessions != 1 || state.SynchronizationQueue != queue {
+		t.Errorf("runtime has %d sessions and synchronization queue %#x, want 1 and %#x",
+			state.LiveSessions, state.SynchronizationQueue, queue)
+	}
+	if err := c.LeaveVrMode(vrApp); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Shutdown(); err == nil {
+		t.Error("Shutdown before DestroySystemVulkan succeeded")
+	}
+	if err := c.DestroySystemVulkan(); err != nil {
+		t.Fatalf("DestroySystemVulkan: %v", err)
+	}
+	if fakevrapi.GetState().VulkanSystem {
+		t.Error("runtime still has a Vulkan system after DestroySystemVulkan")
+	}
+	if err := c.DestroySystemVulkan(); err == nil {
+		t.Error("second DestroySystemVulkan succeeded")
+	}
+	if err := c.Shutdown(); err != nil {
+		t.Errorf("Shutdown: %v", err)
+	}
+}
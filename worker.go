@@ -0,0 +1,385 @@
+package vrapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrWorkerStopped is returned for work submitted to a Context whose Worker
+// has stopped.
+var ErrWorkerStopped = errors.New("worker has stopped")
+
+// PanicError is returned to the caller of Do when the submitted function
+// panicked on the Worker.
+type PanicError struct {
+	Value interface{} // The value passed to panic.
+	Stack []byte      // Stack trace of the Worker at the time of the panic.
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("worker job panicked: %v\n%s", e.Value, e.Stack)
+}
+
+// Size of the work queue so DoAsync rarely has to wait on the Worker.
+const workQueueSize = 64
+
+// Context hands work to its Worker, which runs everything on a single locked
+// OS thread. The vrapi (and EGL) calls have thread affinity so everything
+// touching them has to go through Do or one of its variants.
+//
+// A job must not call back into the Context it was queued on, Do would wait
+// on the job itself forever. Jobs that need to use the Context are queued
+// with DoBound instead.
+type Context struct {
+	workAvailable chan<- struct{}
+	work          chan<- job
+	stopped       <-chan struct{}
+	// Set on the copy DoBound hands to its job.
+	bound *boundJob
+
+	state *contextState
+}
+
+// boundJob is the job a bound Context belongs to.
+type boundJob struct {
+	running int32 // Atomic, 1 until the job returns.
+	// Queued by DoAsync on the bound Context, ran once the job returns.
+	// Only touched by the job so it needs no locking.
+	deferred []deferredJob
+}
+
+type deferredJob struct {
+	fn     func()
+	result chan<- error
+}
+
+// Bookkeeping used to guard against misuse of the runtime. Only ever
+// touched from work ran on the Worker so it needs no locking.
+type contextState struct {
+	initialized bool
+	// From the OVRInitParms passed to Initialize. Allocated on its own since
+	// cgo rejects a pointer into memory that holds Go pointers, like the maps
+	// below.
+	java *OVRJava
+	// Every OVRMobile returned by EnterVrMode. True while in vr mode and
+	// false once LeaveVrMode has been called on it.
+	mobiles map[*OVRMobile]bool
+	// Every swap chain created and not yet destroyed and the format it was
+	// created with, 0 if unknown.
+	swapChains map[OVRTextureSwapChain]SwapChainFormat
+	// Between CreateSystemVulkan and DestroySystemVulkan.
+	vulkan bool
+}
+
+// checkMobile returns an error unless vrApp came from EnterVrMode and has not
+// left vr mode since.
+func (s *contextState) checkMobile(vrApp *OVRMobile) error {
+	inVrMode, ok := s.mobiles[vrApp]
+	if !ok {
+		return fmt.Errorf("OVRMobile %p was not returned by EnterVrMode", vrApp)
+	}
+	if !inVrMode {
+		return ErrLeftVrMode
+	}
+	return nil
+}
+
+func (s *contextState) inVrMode() int {
+	n := 0
+	for _, inVrMode := range s.mobiles {
+		if inVrMode {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *contextState) addSwapChain(swapChain OVRTextureSwapChain, format SwapChainFormat) {
+	if swapChain != 0 {
+		s.swapChains[swapChain] = format
+	}
+}
+
+// checkSwapChain returns an error unless swapChain is live. The runtime's
+// built in swap chains are rejected as well since they can only be
+// submitted, see Layer.swapChains.
+func (s *contextState) checkSwapChain(swapChain OVRTextureSwapChain) error {
+	if IsDefaultTextureSwapChain(swapChain) {
+		return fmt.Errorf("default texture swap chain %#x can only be submitted", swapChain)
+	}
+	if _, ok := s.swapChains[swapChain]; !ok {
+		return fmt.Errorf("texture swap chain %#x: %w", swapChain, ErrSwapChainNotLive)
+	}
+	return nil
+}
+
+type job struct {
+	fn   func()
+	done chan error // Buffered so the Worker never blocks on it.
+	// Atomic, one of the job states below. Whoever moves it out of
+	// jobQueued decides whether fn runs.
+	state *int32
+}
+
+const (
+	jobQueued int32 = iota
+	jobStarted
+	jobAbandoned // The Worker stopped before starting it, fn never runs.
+)
+
+func NewContext() (Context, Worker) {
+	workAvailable := make(chan struct{}, workQueueSize)
+	work := make(chan job, workQueueSize)
+	stopped := make(chan struct{})
+
+	c := Context{
+		workAvailable: workAvailable,
+		work:          work,
+		stopped:       stopped,
+		state: &contextState{
+			mobiles:    make(map[*OVRMobile]bool),
+			swapChains: make(map[OVRTextureSwapChain]SwapChainFormat),
+		},
+	}
+	w := Worker{
+		workAvailable: workAvailable,
+		work:          work,
+		stopped:       stopped,
+		stopOnce:      &sync.Once{},
+	}
+
+	return c, w
+}
+
+// Do runs fn on the Worker and waits for it to finish. It is safe to call
+// from multiple goroutines. Called on a bound Context while its job runs,
+// e.g. the Frame.Context a FrameLoop's Render gets, fn runs right away.
+func (c *Context) Do(fn func()) error {
+	return c.DoContext(context.Background(), fn)
+}
+
+// DoContext is like Do but stops waiting when ctx is done. If fn was already
+// queued it still runs, only the wait is abandoned.
+func (c *Context) DoContext(ctx context.Context, fn func()) error {
+	if c.inJob() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return runJob(fn)
+	}
+
+	j, err := c.submit(ctx, fn)
+	if err != nil {
+		return err
+	}
+	return c.wait(ctx, j)
+}
+
+// DoAsync queues fn on the Worker without waiting for it. The returned
+// channel receives the result once fn has ran. Jobs from a single goroutine
+// run in the order they were queued. Called on a bound Context while its job
+// runs fn is never ran right away, it runs after the job returns and DoAsync
+// does not block on a full queue.
+func (c *Context) DoAsync(fn func()) <-chan error {
+	result := make(chan error, 1)
+
+	if c.inJob() {
+		c.bound.deferred = append(c.bound.deferred, deferredJob{fn: fn, result: result})
+		return result
+	}
+
+	j, err := c.submit(context.Background(), fn)
+	if err != nil {
+		result <- err
+		return result
+	}
+	go func() {
+		result <- c.wait(context.Background(), j)
+	}()
+
+	return result
+}
+
+// DoBound is Do for a job that uses the Context itself. fn gets a copy of c
+// bound to the job: until fn returns Do and DoContext on it run right away
+// on the Worker and DoAsync queues behind the job. Afterwards the copy works
+// like c. Only fn may use it while it runs, not goroutines it starts.
+func (c *Context) DoBound(fn func(bound *Context)) error {
+	if c.inJob() {
+		return runJob(func() { fn(c) })
+	}
+	return c.Do(func() {
+		bound := *c
+		bound.bound = &boundJob{running: 1}
+		defer bound.finish()
+		fn(&bound)
+	})
+}
+
+// inJob reports whether c is bound to a job that is still running.
+func (c *Context) inJob() bool {
+	return c.bound != nil && atomic.LoadInt32(&c.bound.running) == 1
+}
+
+// finish ends the job c is bound to and runs what it queued with DoAsync,
+// unless the Worker was stopped in the meantime.
+func (c *Context) finish() {
+	atomic.StoreInt32(&c.bound.running, 0)
+	for _, j := range c.bound.deferred {
+		select {
+		case <-c.stopped:
+			j.result <- ErrWorkerStopped
+		default:
+			j.result <- runJob(j.fn)
+		}
+	}
+	c.bound.deferred = nil
+}
+
+// doErr is Do for work that can fail.
+func (c *Context) doErr(fn func() error) error {
+	var err error
+	if doErr := c.Do(func() { err = fn() }); doErr != nil {
+		return doErr
+	}
+	return err
+}
+
+func (c *Context) submit(ctx context.Context, fn func()) (job, error) {
+	select {
+	case <-c.stopped:
+		return job{}, ErrWorkerStopped
+	default:
+	}
+
+	j := job{fn: fn, done: make(chan error, 1), state: new(int32)}
+	select {
+	case c.work <- j:
+	case <-c.stopped:
+		return job{}, ErrWorkerStopped
+	case <-ctx.Done():
+		return job{}, ctx.Err()
+	}
+
+	// Every queued job needs exactly one signal so this can't be abandoned.
+	select {
+	case c.workAvailable <- struct{}{}:
+	case <-c.stopped:
+	}
+
+	return j, nil
+}
+
+func (c *Context) wait(ctx context.Context, j job) error {
+	select {
+	case err := <-j.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.stopped:
+		// Only a job the Worker has not started fails, one it is running is
+		// finished before Run returns.
+		if atomic.CompareAndSwapInt32(j.state, jobQueued, jobAbandoned) {
+			return ErrWorkerStopped
+		}
+		return <-j.done
+	}
+}
+
+type Worker struct {
+	workAvailable <-chan struct{}
+	work          <-chan job
+	stopped       chan struct{}
+	stopOnce      *sync.Once
+}
+
+func (w *Worker) WorkAvailable() <-chan struct{} {
+	return w.workAvailable
+}
+
+// Run locks the calling goroutine to its OS thread and does work until ctx is
+// done or Stop is called. Work still pending at that point is rejected with
+// ErrWorkerStopped. Returns ctx.Err() if ctx ended the loop.
+func (w *Worker) Run(ctx context.Context) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+			w.reject()
+			return ctx.Err()
+		case <-w.stopped:
+			w.reject()
+			return nil
+		case <-w.workAvailable:
+			w.DoWork()
+		}
+	}
+}
+
+// DoWork runs one job. Must be called from the thread vrapi is used on (see
+// Run which takes care of that) once WorkAvailable has been received from.
+func (w *Worker) DoWork() {
+	j := <-w.work
+	select {
+	case <-w.stopped:
+		j.abandon()
+		return
+	default:
+	}
+	if !atomic.CompareAndSwapInt32(j.state, jobQueued, jobStarted) {
+		j.done <- ErrWorkerStopped
+		return
+	}
+	j.done <- runJob(j.fn)
+}
+
+func runJob(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+// abandon fails a job the Worker will not start.
+func (j job) abandon() {
+	atomic.CompareAndSwapInt32(j.state, jobQueued, jobAbandoned)
+	j.done <- ErrWorkerStopped
+}
+
+// reject fails every job that was queued but not ran.
+func (w *Worker) reject() {
+	for {
+		select {
+		case j := <-w.work:
+			j.abandon()
+		default:
+			return
+		}
+	}
+}
+
+// Stop makes every pending and future call on the Context fail with
+// ErrWorkerStopped. Safe to call from any goroutine and more than once, Run
+// returns after finishing the job it is currently running.
+func (w *Worker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+	})
+}
+
+// Stopped is closed once Stop has been called.
+func (w *Worker) Stopped() <-chan struct{} {
+	return w.stopped
+}
@@ -0,0 +1,256 @@
+package vrapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// startWorker runs a Worker until the test is done.
+func startWorker(t *testing.T) (*Context, *Worker) {
+	t.Helper()
+	c, w := NewContext()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(context.Background())
+	}()
+	t.Cleanup(func() {
+		w.Stop()
+		<-done
+	})
+	return &c, &w
+}
+
+// Run with -race, count is only touched from the Worker so the detector
+// complains if Do does not order the jobs with their callers.
+func TestDoConcurrentCallers(t *testing.T) {
+	c, _ := startWorker(t)
+
+	const callers, jobs = 8, 200
+	count := 0
+	var async []<-chan error
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < jobs; j++ {
+				var err error
+				switch j % 3 {
+				case 0:
+					err = c.Do(func() { count++ })
+				case 1:
+					err = c.DoContext(context.Background(), func() { count++ })
+				case 2:
+					done := c.DoAsync(func() { count++ })
+					mu.Lock()
+					async = append(async, done)
+					mu.Unlock()
+				}
+				if err != nil {
+					t.Errorf("caller %d job %d: %v", i, j, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, done := range async {
+		if err := <-done; err != nil {
+			t.Errorf("DoAsync: %v", err)
+		}
+	}
+
+	var got int
+	c.Do(func() { got = count })
+	if got != callers*jobs {
+		t.Errorf("%d jobs ran, want %d", got, callers*jobs)
+	}
+}
+
+func TestDoAsyncOrder(t *testing.T) {
+	c, _ := startWorker(t)
+
+	var order []int
+	var last <-chan error
+	for i := 0; i < workQueueSize*2; i++ {
+		i := i
+		last = c.DoAsync(func() { order = append(order, i) })
+	}
+	if err := <-last; err != nil {
+		t.Fatal(err)
+	}
+
+	c.Do(func() {
+		for i, got := range order {
+			if got != i {
+				t.Fatalf("job %d ran at position %d", got, i)
+			}
+		}
+	})
+}
+
+func TestDoBound(t *testing.T) {
+	c, _ := startWorker(t)
+
+	var order []string
+	var async []<-chan error
+	var escaped *Context
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.DoBound(func(bound *Context) {
+			escaped = bound
+			order = append(order, "outer")
+			if err := bound.Do(func() { order = append(order, "Do") }); err != nil {
+				t.Errorf("nested Do: %v", err)
+			}
+			if err := bound.DoContext(context.Background(), func() {
+				order = append(order, "DoContext")
+			}); err != nil {
+				t.Errorf("nested DoContext: %v", err)
+			}
+			if err := bound.DoBound(func(*Context) { order = append(order, "DoBound") }); err != nil {
+				t.Errorf("nested DoBound: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := bound.DoContext(ctx, func() {
+				t.Error("nested DoContext ran with a canceled context")
+			}); !errors.Is(err, context.Canceled) {
+				t.Errorf("nested DoContext with a canceled context returned %v", err)
+			}
+
+			// More than fit in the queue, none may wait on this job.
+			for i := 0; i < workQueueSize*2; i++ {
+				i := i
+				async = append(async, bound.DoAsync(func() {
+					order = append(order, fmt.Sprint("async ", i))
+				}))
+			}
+
+			var panicErr *PanicError
+			if err := bound.Do(func() { panic("nested") }); !errors.As(err, &panicErr) {
+				t.Errorf("nested Do that panicked returned %v, want PanicError", err)
+			}
+			order = append(order, "outer done")
+		})
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("calling back into a bound Context deadlocked")
+	}
+	for _, done := range async {
+		if err := <-done; err != nil {
+			t.Errorf("DoAsync queued from the Worker: %v", err)
+		}
+	}
+
+	want := []string{"outer", "Do", "DoContext", "DoBound", "outer done"}
+	for i := 0; i < workQueueSize*2; i++ {
+		want = append(want, fmt.Sprint("async ", i))
+	}
+	var got []string
+	c.Do(func() { got = order })
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("jobs ran in order %q, want %q", got, want)
+	}
+
+	// Once its job returned the bound Context queues like any other.
+	release := make(chan struct{})
+	c.DoAsync(func() { <-release })
+	ran := make(chan struct{})
+	go func() {
+		escaped.Do(func() {})
+		close(ran)
+	}()
+	select {
+	case <-ran:
+		t.Error("bound Context ran a job inline after its job returned")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	<-ran
+}
+
+func TestDoPanic(t *testing.T) {
+	c, _ := startWorker(t)
+
+	var panicErr *PanicError
+	if err := c.Do(func() { panic("boom") }); !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("Do returned %v, want PanicError with boom", err)
+	}
+	if err := c.Do(func() {}); err != nil {
+		t.Errorf("Do after a panic: %v", err)
+	}
+}
+
+func TestDoStopped(t *testing.T) {
+	c, w := startWorker(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocked := c.DoAsync(func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.Do(func() {})
+		}()
+	}
+
+	w.Stop()
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	// The running job finishes, everything behind it fails.
+	if err := <-blocked; err != nil {
+		t.Errorf("job running when stopped: %v", err)
+	}
+	for err := range errs {
+		if !errors.Is(err, ErrWorkerStopped) {
+			t.Errorf("Do while stopping returned %v, want ErrWorkerStopped", err)
+		}
+	}
+	if err := c.Do(func() {}); !errors.Is(err, ErrWorkerStopped) {
+		t.Errorf("Do after Stop returned %v, want ErrWorkerStopped", err)
+	}
+}
+
+func TestDoContextCanceled(t *testing.T) {
+	c, _ := startWorker(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	c.DoAsync(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.DoContext(ctx, func() {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DoContext returned %v, want DeadlineExceeded", err)
+	}
+}